@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/winartodev/go-grpc/config"
+	"github.com/winartodev/go-grpc/interceptor"
+	"github.com/winartodev/go-grpc/logging"
+	"github.com/winartodev/go-grpc/ratelimit"
+)
+
+// defaultMaxIdleConns is the database/sql default, which SetMaxIdleConns(0) would replace
+// with no idle connections at all.
+const defaultMaxIdleConns = 2
+
+// runtimeSettings applies the reloadable part of the config to the running server.
+type runtimeSettings struct {
+	logLevel    *slog.LevelVar
+	db          *sql.DB
+	rateLimiter *interceptor.RateLimitInterceptor
+}
+
+// apply sets the reloadable settings from c. The rate limiters and the quota are only replaced
+// when one of their keys is in changed, since a new limiter starts with full buckets and a new
+// quota with empty windows.
+func (rs *runtimeSettings) apply(c *config.Config, changed []string) {
+	if level, err := logging.ParseLevel(c.Log.Level); err == nil {
+		rs.logLevel.Set(level)
+	}
+
+	rs.db.SetMaxOpenConns(c.Database.MaxOpenConns)
+	maxIdleConns := c.Database.MaxIdleConns
+	if maxIdleConns == 0 {
+		maxIdleConns = defaultMaxIdleConns
+	}
+	rs.db.SetMaxIdleConns(maxIdleConns)
+	if lifetime, err := parseDuration(c.Database.ConnMaxLifetime); err == nil {
+		rs.db.SetConnMaxLifetime(lifetime)
+	}
+
+	if anyChanged(changed, "rate_limit.enabled", "rate_limit.quota.requests", "rate_limit.quota.period") {
+		var quota *ratelimit.Quota
+		if period, err := parseDuration(c.RateLimit.Quota.Period); err == nil && c.RateLimit.Enabled && c.RateLimit.Quota.Requests > 0 {
+			quota = ratelimit.NewQuota(c.RateLimit.Quota.Requests, period)
+		}
+		rs.rateLimiter.SetQuota(quota)
+	}
+
+	if anyChanged(changed, "rate_limit.enabled", "rate_limit.rate", "rate_limit.burst", "rate_limit.methods") {
+		if !c.RateLimit.Enabled {
+			rs.rateLimiter.SetLimiters(nil, nil)
+			return
+		}
+
+		methodLimiters := make(map[string]*ratelimit.Limiter)
+		for method, limit := range c.RateLimit.Methods {
+			methodLimiters[method] = ratelimit.NewLimiter(limit.Rate, limit.Burst)
+		}
+
+		rs.rateLimiter.SetLimiters(ratelimit.NewLimiter(c.RateLimit.Rate, c.RateLimit.Burst), methodLimiters)
+	}
+}
+
+func anyChanged(changed []string, keys ...string) bool {
+	for _, key := range keys {
+		if slices.Contains(changed, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// watchConfig reloads the config with load on SIGHUP and whenever its files change, until ctx
+// is done.
+func watchConfig(ctx context.Context, current *config.Config, load func() (*config.Config, error), apply func(c *config.Config, changed []string)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	config.NewWatcher(current, load, apply).Watch(ctx, config.DefaultWatchInterval, hup)
+}
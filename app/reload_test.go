@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/winartodev/go-grpc/config"
+	"github.com/winartodev/go-grpc/interceptor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRuntimeSettings_Apply(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	settings := &runtimeSettings{
+		logLevel:    &slog.LevelVar{},
+		db:          db,
+		rateLimiter: interceptor.NewRateLimitInterceptor(nil, nil),
+	}
+
+	call := func() codes.Code {
+		_, err := settings.rateLimiter.Unary()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/todolist.Todo/GetListTask"},
+			func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+		return status.Code(err)
+	}
+
+	cfg := &config.Config{}
+	cfg.Log.Level = "info"
+	cfg.RateLimit.Enabled = true
+	cfg.RateLimit.Burst = 1
+	settings.apply(cfg, config.Reloadable)
+
+	if first, second := call(), call(); first != codes.OK || second != codes.ResourceExhausted {
+		t.Fatalf("codes = %v, %v, want %v, %v", first, second, codes.OK, codes.ResourceExhausted)
+	}
+
+	cfg.Log.Level = "debug"
+	settings.apply(cfg, []string{"log.level"})
+	if got := call(); got != codes.ResourceExhausted {
+		t.Errorf("after an unrelated reload code = %v, want %v, the bucket was reset", got, codes.ResourceExhausted)
+	}
+	if settings.logLevel.Level() != slog.LevelDebug {
+		t.Errorf("log level = %v, want %v", settings.logLevel.Level(), slog.LevelDebug)
+	}
+
+	cfg.RateLimit.Burst = 2
+	settings.apply(cfg, []string{"rate_limit.burst"})
+	if got := call(); got != codes.OK {
+		t.Errorf("after changing the burst code = %v, want %v", got, codes.OK)
+	}
+}
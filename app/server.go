@@ -0,0 +1,583 @@
+// Package app assembles the todo gRPC server from its config, so that it can be run by the
+// todolist binary, embedded in other binaries or booted by integration tests.
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/authz"
+	"github.com/winartodev/go-grpc/config"
+	"github.com/winartodev/go-grpc/gateway"
+	todoHandler "github.com/winartodev/go-grpc/handler"
+	"github.com/winartodev/go-grpc/healthcheck"
+	"github.com/winartodev/go-grpc/interceptor"
+	"github.com/winartodev/go-grpc/logging"
+	"github.com/winartodev/go-grpc/metrics"
+	"github.com/winartodev/go-grpc/repository/indexed"
+	"github.com/winartodev/go-grpc/repository/instrumented"
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	tracedRepository "github.com/winartodev/go-grpc/repository/traced"
+	"github.com/winartodev/go-grpc/storage"
+	"github.com/winartodev/go-grpc/storage/local"
+	"github.com/winartodev/go-grpc/tlsconfig"
+	"github.com/winartodev/go-grpc/tracing"
+	"github.com/winartodev/go-grpc/usecase"
+	tracedUsecase "github.com/winartodev/go-grpc/usecase/traced"
+	"github.com/winartodev/go-grpc/workflow"
+	"github.com/winartodev/protobuff-collections/todolist"
+	"golang.org/x/net/netutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// shutdownTimeout bounds the flush of each remaining component once gRPC has stopped.
+const shutdownTimeout = 5 * time.Second
+
+// Server is the todo gRPC server with its health, metrics and background workers.
+type Server struct {
+	Config *config.Config
+	Logger *slog.Logger
+	DB     *sql.DB
+	GRPC   *grpc.Server
+	Health *health.Server
+
+	listener      net.Listener
+	metricsServer *http.Server
+
+	gatewayServer   *http.Server
+	gatewayListener net.Listener
+	settings        *runtimeSettings
+	options         options
+
+	probe          *healthcheck.Probe
+	tlsReloader    *tlsconfig.Reloader
+	drainTimeout   time.Duration
+	preDrainDelay  time.Duration
+	closeTracing   func(context.Context) error
+	stopWorkers    context.CancelFunc
+	workersCtx     context.Context
+	healthServices []string
+
+	shutdownOnce sync.Once
+}
+
+type options struct {
+	db                 *sql.DB
+	listener           net.Listener
+	blobStorage        storage.BlobStorageInterface
+	logWriter          io.Writer
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	reloadConfig       func() (*config.Config, error)
+}
+
+// Option customizes the backends and wiring of a Server.
+type Option func(o *options)
+
+// WithDB uses db instead of opening the database from the config. The Server closes it on
+// shutdown.
+func WithDB(db *sql.DB) Option {
+	return func(o *options) {
+		o.db = db
+	}
+}
+
+// WithListener serves on lis instead of listening on the configured address.
+func WithListener(lis net.Listener) Option {
+	return func(o *options) {
+		o.listener = lis
+	}
+}
+
+// WithBlobStorage stores attachments in blobStorage instead of the configured storage.
+func WithBlobStorage(blobStorage storage.BlobStorageInterface) Option {
+	return func(o *options) {
+		o.blobStorage = blobStorage
+	}
+}
+
+// WithLogWriter writes the logs to w instead of standard error.
+func WithLogWriter(w io.Writer) Option {
+	return func(o *options) {
+		o.logWriter = w
+	}
+}
+
+// WithUnaryInterceptors appends interceptors to the end of the built-in chain, right before
+// the handlers.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(o *options) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors is WithUnaryInterceptors for streaming calls.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(o *options) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+// WithConfigReload enables hot reload: load is called on SIGHUP and when the config files
+// change, and the reloadable settings of its result are applied.
+func WithConfigReload(load func() (*config.Config, error)) Option {
+	return func(o *options) {
+		o.reloadConfig = load
+	}
+}
+
+// New wires the server described by cfg. Nothing is served until Run is called, but the
+// listener is already bound, so Addr reports the actual address even for port 0.
+func New(cfg *config.Config, opts ...Option) (server *Server, err error) {
+	s := &Server{
+		Config:         cfg,
+		healthServices: []string{"", todolist.Todo_ServiceDesc.ServiceName},
+	}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+
+	// Undo what was set up so far when a later step fails.
+	defer func() {
+		if err != nil {
+			s.close()
+		}
+	}()
+
+	s.workersCtx, s.stopWorkers = context.WithCancel(context.Background())
+
+	logWriter := s.options.logWriter
+	if logWriter == nil {
+		logWriter = os.Stderr
+	}
+
+	var logLevel slog.LevelVar
+	level, err := logging.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		return nil, err
+	}
+	logLevel.Set(level)
+
+	s.Logger, err = logging.NewWithLevel(logWriter, &logLevel, cfg.Log.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	s.closeTracing, err = tracing.Setup(context.Background(), cfg.Tracing.Exporter, cfg.Tracing.Endpoint, cfg.Tracing.ServiceName, cfg.Tracing.SampleRatio)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
+	}
+
+	s.DB = s.options.db
+	if s.DB == nil {
+		dsn, err := cfg.DSN()
+		if err != nil {
+			return nil, fmt.Errorf("database: %w", err)
+		}
+
+		s.DB, err = sql.Open(cfg.Database.Driver, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("open database: %w", err)
+		}
+	}
+
+	appMetrics := metrics.NewMetrics()
+
+	attachmentRepository := todoRepository.NewAttachmentRepository(s.DB)
+	apiKeyRepository := todoRepository.NewAPIKeyRepository(s.DB)
+	todoRepository := todoRepository.NewTodoRepository(s.DB)
+
+	if cfg.Metrics.Enabled {
+		appMetrics.RegisterDB(s.DB, cfg.Database.Name)
+
+		attachmentRepository = instrumented.NewAttachmentRepository(attachmentRepository, appMetrics)
+		apiKeyRepository = instrumented.NewAPIKeyRepository(apiKeyRepository, appMetrics)
+		todoRepository = instrumented.NewTodoRepository(todoRepository, appMetrics)
+	}
+
+	todoRepository = tracedRepository.NewTodoRepository(todoRepository)
+
+	authorizer := authz.AllowAll()
+	if cfg.Auth.Enabled {
+		authorizer = authz.NewRoleAuthorizer(cfg.Auth.DefaultRole)
+	}
+
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepository, authorizer)
+
+	serverOptions, unaryInterceptors, rateLimitInterceptor, err := s.serverOptions(appMetrics, apiKeyUsecase)
+	if err != nil {
+		return nil, err
+	}
+
+	s.settings = &runtimeSettings{
+		logLevel:    &logLevel,
+		db:          s.DB,
+		rateLimiter: rateLimitInterceptor,
+	}
+	s.settings.apply(cfg, config.Reloadable)
+
+	s.GRPC = grpc.NewServer(serverOptions...)
+
+	blobStorage := s.options.blobStorage
+	if blobStorage == nil {
+		switch cfg.Attachment.Storage {
+		case "", "local":
+			blobStorage = local.NewBlobStorage(cfg.Attachment.LocalPath)
+		default:
+			return nil, fmt.Errorf("unknown attachment storage %q", cfg.Attachment.Storage)
+		}
+	}
+
+	taskWorkflow := workflow.Default()
+	if cfg.Workflow.Initial != "" {
+		taskWorkflow, err = workflow.New(cfg.Workflow.Initial, cfg.Workflow.Done, cfg.Workflow.Transitions)
+		if err != nil {
+			return nil, fmt.Errorf("workflow: %w", err)
+		}
+	}
+
+	switch cfg.Search.Backend {
+	case "", "fulltext":
+	case "index":
+		todoRepository, err = indexed.NewTodoRepository(context.Background(), todoRepository)
+		if err != nil {
+			return nil, fmt.Errorf("build search index: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown search backend %q", cfg.Search.Backend)
+	}
+
+	attachmentUsecase := usecase.NewAttachmentUsecase(attachmentRepository, todoRepository, blobStorage, cfg.Attachment.MaxSize, authorizer)
+	todoUsecase := tracedUsecase.NewTodoUsecase(usecase.NewTodoUsecase(todoRepository, attachmentUsecase, taskWorkflow, authorizer))
+
+	todoServer := todoHandler.NewTodoHandler(s.GRPC, todoUsecase, attachmentUsecase, apiKeyUsecase)
+
+	probeInterval, err := parseDuration(cfg.Health.ProbeInterval)
+	if err != nil {
+		return nil, fmt.Errorf("health probe_interval: %w", err)
+	}
+
+	probeTimeout, err := parseDuration(cfg.Health.ProbeTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("health probe_timeout: %w", err)
+	}
+
+	s.drainTimeout, err = parseDuration(cfg.Shutdown.DrainTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("shutdown drain_timeout: %w", err)
+	}
+
+	s.preDrainDelay, err = parseDuration(cfg.Shutdown.PreDrainDelay)
+	if err != nil {
+		return nil, fmt.Errorf("shutdown pre_drain_delay: %w", err)
+	}
+
+	// Both the server as a whole and the todo service stay NOT_SERVING until the first
+	// successful database ping.
+	s.Health = health.NewServer()
+	for _, service := range s.healthServices {
+		s.Health.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	healthpb.RegisterHealthServer(s.GRPC, s.Health)
+
+	s.probe = healthcheck.NewProbe(s.DB, s.Health, probeInterval, probeTimeout, s.healthServices...)
+
+	if cfg.Metrics.Enabled {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", appMetrics.Handler())
+
+		s.metricsServer = &http.Server{
+			Addr:              cfg.Metrics.Address,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+	}
+
+	// The gateway is plain HTTP, TLS is expected to be terminated in front of it.
+	if cfg.Gateway.Enabled {
+		s.gatewayServer = &http.Server{
+			Handler:           gateway.NewGateway(todoServer, unaryInterceptors, cfg.Gateway.AllowedOrigins),
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+		}
+	}
+
+	s.listener = s.options.listener
+	if s.listener == nil {
+		s.listener, err = net.Listen("tcp", net.JoinHostPort(cfg.TodoList.Host, cfg.TodoList.Port))
+		if err != nil {
+			return nil, fmt.Errorf("listen: %w", err)
+		}
+	}
+
+	if s.gatewayServer != nil {
+		s.gatewayListener, err = net.Listen("tcp", cfg.Gateway.Address)
+		if err != nil {
+			if s.options.listener == nil {
+				s.listener.Close()
+			}
+			return nil, fmt.Errorf("listen gateway: %w", err)
+		}
+
+		s.gatewayListener = netutil.LimitListener(s.gatewayListener, cfg.Gateway.MaxConnections)
+	}
+
+	return s, nil
+}
+
+// serverOptions builds the interceptor chain: logging, tracing, metrics, recovery, status,
+// auth, rate limiting and then the extra interceptors of the options. The unary chain is also
+// returned for the gateway, so that HTTP calls go through the same interceptors.
+func (s *Server) serverOptions(appMetrics *metrics.Metrics, apiKeyUsecase usecase.APIKeyUsecaseInterface) ([]grpc.ServerOption, []grpc.UnaryServerInterceptor, *interceptor.RateLimitInterceptor, error) {
+	cfg := s.Config
+
+	loggingInterceptor := interceptor.NewLoggingInterceptor(s.Logger, cfg.Log.SampleRate)
+
+	unary := []grpc.UnaryServerInterceptor{loggingInterceptor.Unary(), interceptor.TracingUnaryInterceptor}
+	stream := []grpc.StreamServerInterceptor{loggingInterceptor.Stream(), interceptor.TracingStreamInterceptor}
+
+	var panicObserver interceptor.PanicObserver
+	if cfg.Metrics.Enabled {
+		metricsInterceptor := interceptor.NewMetricsInterceptor(appMetrics)
+		unary = append(unary, metricsInterceptor.Unary())
+		stream = append(stream, metricsInterceptor.Stream())
+		panicObserver = appMetrics
+	}
+
+	recoveryInterceptor := interceptor.NewRecoveryInterceptor(s.Logger, panicObserver)
+	unary = append(unary, recoveryInterceptor.Unary(), interceptor.StatusUnaryInterceptor)
+	stream = append(stream, recoveryInterceptor.Stream(), interceptor.StatusStreamInterceptor)
+
+	var opts []grpc.ServerOption
+
+	tlsConfig := cfg.TodoList.TLS
+	switch {
+	case tlsConfig.CertFile != "":
+		minVersion, err := tlsconfig.ParseMinVersion(tlsConfig.MinVersion)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("tls: %w", err)
+		}
+
+		s.tlsReloader, err = tlsconfig.NewReloader(tlsConfig.CertFile, tlsConfig.KeyFile, tlsConfig.ClientCAFile)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("load tls certificates: %w", err)
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsReloader.TLSConfig(minVersion))))
+	case tlsConfig.Insecure:
+		s.Logger.Warn("tls is disabled, serving plaintext gRPC")
+	default:
+		return nil, nil, nil, errors.New("tls cert_file is required unless todolist.tls.insecure is set")
+	}
+
+	if cfg.Auth.Enabled {
+		authenticator, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+			HMACSecret:   cfg.Auth.HMACSecret,
+			RSAPublicKey: cfg.Auth.RSAPublicKey,
+			JWKSFile:     cfg.Auth.JWKSFile,
+			Issuer:       cfg.Auth.Issuer,
+			Audience:     cfg.Auth.Audience,
+		})
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("auth: %w", err)
+		}
+
+		authInterceptor := interceptor.NewAuthInterceptor(authenticator, apiKeyUsecase, cfg.Auth.Allowlist)
+		unary = append(unary, authInterceptor.Unary())
+		stream = append(stream, authInterceptor.Stream())
+	}
+
+	// Always installed so that a config reload can turn rate limiting on and off.
+	rateLimitInterceptor := interceptor.NewRateLimitInterceptor(nil, nil)
+	unary = append(unary, rateLimitInterceptor.Unary())
+	stream = append(stream, rateLimitInterceptor.Stream())
+
+	unary = append(unary, s.options.unaryInterceptors...)
+	stream = append(stream, s.options.streamInterceptors...)
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+
+	return opts, unary, rateLimitInterceptor, nil
+}
+
+// Addr is the address gRPC is served on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// GatewayAddr is the address the HTTP/JSON gateway is served on, nil when it is disabled.
+func (s *Server) GatewayAddr() net.Addr {
+	if s.gatewayListener == nil {
+		return nil
+	}
+
+	return s.gatewayListener.Addr()
+}
+
+// Run serves until ctx is done or a server fails, then shuts down, giving in-flight calls the
+// configured drain timeout. It returns the error of the failed server, if any.
+func (s *Server) Run(ctx context.Context) error {
+	errs := make(chan error, 3)
+
+	go s.probe.Run(s.workersCtx)
+
+	if s.tlsReloader != nil {
+		go s.tlsReloader.Watch(s.workersCtx, tlsconfig.DefaultReloadInterval)
+	}
+
+	if s.options.reloadConfig != nil {
+		go watchConfig(s.workersCtx, s.Config, s.options.reloadConfig, s.settings.apply)
+	}
+
+	s.Logger.Info("server started", "address", s.Addr().String())
+
+	go func() {
+		if err := s.GRPC.Serve(s.listener); err != nil {
+			errs <- fmt.Errorf("serve gRPC: %w", err)
+			return
+		}
+		errs <- nil
+	}()
+
+	if s.metricsServer != nil {
+		s.Logger.Info("metrics server started", "address", s.metricsServer.Addr)
+
+		go func() {
+			if err := s.metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				errs <- fmt.Errorf("serve metrics: %w", err)
+			}
+		}()
+	}
+
+	if s.gatewayServer != nil {
+		s.Logger.Info("gateway started", "address", s.GatewayAddr().String())
+
+		go func() {
+			if err := s.gatewayServer.Serve(s.gatewayListener); err != nil && err != http.ErrServerClosed {
+				errs <- fmt.Errorf("serve gateway: %w", err)
+			}
+		}()
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), s.preDrainDelay+s.drainTimeout)
+	defer cancel()
+
+	s.Shutdown(drainCtx)
+
+	return err
+}
+
+// Shutdown flips the health status to NOT_SERVING, keeps serving for the pre-drain delay, lets
+// in-flight calls and gateway requests finish until ctx is done, forcing the remaining ones
+// closed after that, then stops the workers and closes the metrics server, the tracer and the
+// database, in that order. Only the first call has an effect.
+func (s *Server) Shutdown(ctx context.Context) {
+	s.shutdownOnce.Do(func() {
+		s.Logger.Info("shutting down")
+
+		// Stop the workers first so the probe cannot flip the health status back, then tell
+		// load balancers to stop sending traffic before draining.
+		s.stopWorkers()
+		s.Health.Shutdown()
+
+		// Load balancers only see NOT_SERVING on their next check, new calls keep coming in
+		// until then.
+		if s.preDrainDelay > 0 {
+			s.Logger.Info("waiting before draining", "delay", s.preDrainDelay)
+			select {
+			case <-time.After(s.preDrainDelay):
+			case <-ctx.Done():
+			}
+		}
+
+		// The gateway calls the handlers directly, so its requests drain alongside gRPC.
+		var gatewayDone chan struct{}
+		if s.gatewayServer != nil {
+			gatewayDone = make(chan struct{})
+			go func() {
+				defer close(gatewayDone)
+				if err := s.gatewayServer.Shutdown(ctx); err != nil {
+					s.Logger.Warn("drain timeout exceeded, closed remaining gateway requests", "error", err)
+					s.gatewayServer.Close()
+				}
+			}()
+		}
+
+		if !stopGRPC(ctx, s.GRPC) {
+			s.Logger.Warn("drain timeout exceeded, closed remaining calls")
+		}
+
+		if gatewayDone != nil {
+			<-gatewayDone
+		}
+
+		s.close()
+
+		s.Logger.Info("server gracefully stopped")
+	})
+}
+
+// close releases everything but the gRPC server, which is also how New cleans up after a
+// failure, hence the nil checks.
+func (s *Server) close() {
+	if s.stopWorkers != nil {
+		s.stopWorkers()
+	}
+
+	if s.metricsServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		if err := s.metricsServer.Shutdown(ctx); err != nil {
+			s.Logger.Error("failed to stop metrics server", "error", err)
+		}
+		cancel()
+	}
+
+	if s.closeTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		if err := s.closeTracing(ctx); err != nil {
+			s.Logger.Error("failed to flush traces", "error", err)
+		}
+		cancel()
+	}
+
+	if s.DB != nil {
+		if err := s.DB.Close(); err != nil {
+			s.Logger.Error("failed to close database", "error", err)
+		}
+	}
+}
+
+// parseDuration parses a duration from the config, where empty means the default.
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/winartodev/go-grpc/config"
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	"github.com/winartodev/protobuff-collections/todolist"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func testConfig(t *testing.T) *config.Config {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "todolist:\n  port: 0\n  tls:\n    insecure: true\ndatabase:\n  name: todo-db\n  username: root\nlog:\n  level: error\nmetrics:\n  enabled: false\ngateway:\n  enabled: true\n  address: 127.0.0.1:0\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{}
+	if err := cfg.Load(path, func(string) (string, bool) { return "", false }, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	return cfg
+}
+
+func TestServer_Run(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createdAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(todoRepository.GetTaskByID)).
+		WithArgs("", int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at"}).
+			AddRow(1, "write tests", "todo", false, "m", "", "alice", createdAt, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(todoRepository.GetTaskByID)).
+		WithArgs("", int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at"}).
+			AddRow(1, "write tests", "todo", false, "m", "", "alice", createdAt, nil))
+	mock.ExpectClose()
+
+	server, err := New(testConfig(t), WithDB(db), WithLogWriter(io.Discard))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- server.Run(ctx)
+	}()
+
+	conn, err := grpc.Dial(server.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	healthClient := healthpb.NewHealthClient(conn)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		res, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: todolist.Todo_ServiceDesc.ServiceName})
+		if err == nil && res.Status == healthpb.HealthCheckResponse_SERVING {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("health never became SERVING: %v, %v", res, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	res, err := todolist.NewTodoClient(conn).GetTaskByID(context.Background(), &todolist.GetTaskByIDRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("GetTaskByID() error = %v", err)
+	}
+	if res.Task.Description != "write tests" {
+		t.Errorf("GetTaskByID() = %v, want the task from the database", res.Task)
+	}
+
+	httpRes, err := http.Get("http://" + server.GatewayAddr().String() + "/v1/tasks/1")
+	if err != nil {
+		t.Fatalf("GET /v1/tasks/1 error = %v", err)
+	}
+	body, _ := io.ReadAll(httpRes.Body)
+	httpRes.Body.Close()
+	if httpRes.StatusCode != http.StatusOK || !strings.Contains(string(body), `"description":"write tests"`) {
+		t.Errorf("GET /v1/tasks/1 = %v %s, want the task from the database", httpRes.StatusCode, body)
+	}
+
+	cancel()
+	select {
+	case err := <-runErr:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after ctx was cancelled")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := testConfig(t)
+	cfg.TodoList.TLS.Insecure = false
+
+	if _, err := New(cfg, WithDB(db), WithLogWriter(io.Discard)); err == nil {
+		t.Error("New() error = nil, want an error for missing tls certificates")
+	}
+}
+
+func TestServer_Shutdown_PreDrainDelay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(todoRepository.GetTaskByID)).
+		WithArgs("", int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at"}).
+			AddRow(1, "write tests", "todo", false, "m", "", "alice", time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC), nil))
+	mock.ExpectClose()
+
+	cfg := testConfig(t)
+	cfg.Shutdown.PreDrainDelay = "2s"
+	server, err := New(cfg, WithDB(db), WithLogWriter(io.Discard))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- server.Run(ctx)
+	}()
+
+	conn, err := grpc.Dial(server.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	healthClient := healthpb.NewHealthClient(conn)
+	waitFor := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			res, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{})
+			if err == nil && res.Status == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("health never became %v: %v, %v", want, res, err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	waitFor(healthpb.HealthCheckResponse_SERVING)
+	cancel()
+	waitFor(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	if _, err := todolist.NewTodoClient(conn).GetTaskByID(context.Background(), &todolist.GetTaskByIDRequest{Id: 1}); err != nil {
+		t.Errorf("GetTaskByID() during the pre-drain delay error = %v, want it served", err)
+	}
+
+	select {
+	case err := <-runErr:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run() did not return after the pre-drain delay")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// stopGRPC lets in-flight calls and streams finish until ctx is done, then closes whatever is
+// left. It reports whether every call finished in time.
+func stopGRPC(ctx context.Context, server *grpc.Server) bool {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		server.Stop()
+		<-done
+		return false
+	}
+}
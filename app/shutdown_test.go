@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestStopGRPC(t *testing.T) {
+	tests := []struct {
+		name       string
+		openStream bool
+		want       bool
+	}{
+		{
+			name:       "Drained",
+			openStream: false,
+			want:       true,
+		},
+		{
+			name:       "Forced After Timeout",
+			openStream: true,
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			server := grpc.NewServer()
+			healthpb.RegisterHealthServer(server, health.NewServer())
+			go server.Serve(lis)
+
+			conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			if tt.openStream {
+				// Watch streams until the client goes away, so GracefulStop alone never returns.
+				stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := stream.Recv(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			if got := stopGRPC(ctx, server); got != tt.want {
+				t.Errorf("stopGRPC() = %v, want %v", got, tt.want)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("stopGRPC() took %v", elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.Claims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	return signed
+}
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	content, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	err = os.WriteFile(path, content, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := NewJWTAuthenticator(JWTConfig{
+		HMACSecret: "secret",
+		JWKSFile:   writeJWKS(t, "key-1", &rsaKey.PublicKey),
+		Issuer:     "todo",
+	})
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator() error = %v", err)
+	}
+
+	valid := jwt.RegisteredClaims{
+		Subject:   "alice",
+		Issuer:    "todo",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	wrongIssuer := valid
+	wrongIssuer.Issuer = "other"
+
+	noSubject := valid
+	noSubject.Subject = ""
+
+	tests := []struct {
+		name    string
+		token   string
+		want    Principal
+		wantErr bool
+	}{
+		{
+			name:  "HS256",
+			token: signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", valid),
+			want:  Principal{UserID: "alice"},
+		},
+		{
+			name:  "RS256 From JWKS",
+			token: signToken(t, jwt.SigningMethodRS256, rsaKey, "key-1", valid),
+			want:  Principal{UserID: "alice"},
+		},
+		{
+			name:  "Role And Tenant Claims",
+			token: signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", claims{RegisteredClaims: valid, Role: "admin", Tenant: "acme"}),
+			want:  Principal{UserID: "alice", Role: "admin", TenantID: "acme"},
+		},
+		{
+			name:    "Wrong HMAC Secret",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte("other"), "", valid),
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Key ID",
+			token:   signToken(t, jwt.SigningMethodRS256, rsaKey, "key-2", valid),
+			wantErr: true,
+		},
+		{
+			name:    "Wrong RSA Key",
+			token:   signToken(t, jwt.SigningMethodRS256, otherKey, "key-1", valid),
+			wantErr: true,
+		},
+		{
+			name:    "Disallowed Method",
+			token:   signToken(t, jwt.SigningMethodHS512, []byte("secret"), "", valid),
+			wantErr: true,
+		},
+		{
+			name:    "Expired",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", expired),
+			wantErr: true,
+		},
+		{
+			name:    "Wrong Issuer",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", wrongIssuer),
+			wantErr: true,
+		},
+		{
+			name:    "Missing Subject",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", noSubject),
+			wantErr: true,
+		},
+		{
+			name:    "Malformed",
+			token:   "not-a-token",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := authenticator.Authenticate(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewJWTAuthenticator_NoKeys(t *testing.T) {
+	_, err := NewJWTAuthenticator(JWTConfig{})
+	if err == nil {
+		t.Errorf("NewJWTAuthenticator() error = nil, want error")
+	}
+}
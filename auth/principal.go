@@ -0,0 +1,30 @@
+package auth
+
+import "context"
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID   string
+	Role     string
+	TenantID string
+	// Scopes restricts the actions of API key callers, empty means unrestricted.
+	Scopes []string
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func FromContext(ctx context.Context) (principal Principal, ok bool) {
+	principal, ok = ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// TenantFromContext returns the tenant of the caller. Unauthenticated requests and callers
+// without a tenant share the default tenant, the empty string.
+func TenantFromContext(ctx context.Context) string {
+	principal, _ := FromContext(ctx)
+	return principal.TenantID
+}
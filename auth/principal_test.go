@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		want   Principal
+		wantOk bool
+	}{
+		{
+			name:   "Authenticated",
+			ctx:    NewContext(context.Background(), Principal{UserID: "alice"}),
+			want:   Principal{UserID: "alice"},
+			wantOk: true,
+		},
+		{
+			name:   "Anonymous",
+			ctx:    context.Background(),
+			want:   Principal{},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FromContext(tt.ctx)
+			if ok != tt.wantOk || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromContext() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/types"
+)
+
+func TestRoleAuthorizer_Authorize(t *testing.T) {
+	ownTask := &types.Task{ID: 1, CreatorID: "alice"}
+	assignedTask := &types.Task{ID: 2, CreatorID: "bob", AssigneeID: "alice"}
+	otherTask := &types.Task{ID: 3, CreatorID: "bob"}
+
+	type args struct {
+		principal *auth.Principal
+		action    Action
+		task      *types.Task
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name:    "Anonymous",
+			args:    args{action: ActionRead},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name: "Viewer Reads",
+			args: args{principal: &auth.Principal{UserID: "alice", Role: RoleViewer}, action: ActionRead, task: otherTask},
+		},
+		{
+			name:    "Viewer Cannot Create",
+			args:    args{principal: &auth.Principal{UserID: "alice", Role: RoleViewer}, action: ActionCreate},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name:    "Default Role Applies",
+			args:    args{principal: &auth.Principal{UserID: "alice"}, action: ActionUpdate, task: ownTask},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name: "Editor Creates",
+			args: args{principal: &auth.Principal{UserID: "alice", Role: RoleEditor}, action: ActionCreate},
+		},
+		{
+			name: "Editor Updates Assigned Task",
+			args: args{principal: &auth.Principal{UserID: "alice", Role: RoleEditor}, action: ActionUpdate, task: assignedTask},
+		},
+		{
+			name:    "Editor Cannot Update Other Task",
+			args:    args{principal: &auth.Principal{UserID: "alice", Role: RoleEditor}, action: ActionUpdate, task: otherTask},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name: "Editor Deletes Own Task",
+			args: args{principal: &auth.Principal{UserID: "alice", Role: RoleEditor}, action: ActionDelete, task: ownTask},
+		},
+		{
+			name:    "Editor Cannot Delete Assigned Task",
+			args:    args{principal: &auth.Principal{UserID: "alice", Role: RoleEditor}, action: ActionDelete, task: assignedTask},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name: "Admin Deletes Any Task",
+			args: args{principal: &auth.Principal{UserID: "alice", Role: RoleAdmin}, action: ActionDelete, task: otherTask},
+		},
+		{
+			name: "Scoped Key Reads",
+			args: args{principal: &auth.Principal{UserID: "apikey:1", Role: RoleAdmin, Scopes: []string{"read"}}, action: ActionRead, task: otherTask},
+		},
+		{
+			name:    "Scoped Key Cannot Delete",
+			args:    args{principal: &auth.Principal{UserID: "apikey:1", Role: RoleAdmin, Scopes: []string{"read"}}, action: ActionDelete, task: otherTask},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name:    "Only Admins Manage API Keys",
+			args:    args{principal: &auth.Principal{UserID: "alice", Role: RoleEditor}, action: ActionManageAPIKeys},
+			wantErr: ErrPermissionDenied,
+		},
+		{
+			name:    "Unknown Role",
+			args:    args{principal: &auth.Principal{UserID: "alice", Role: "owner"}, action: ActionRead},
+			wantErr: ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.args.principal != nil {
+				ctx = auth.NewContext(ctx, *tt.args.principal)
+			}
+
+			ra := NewRoleAuthorizer(RoleViewer)
+			err := ra.Authorize(ctx, tt.args.action, tt.args.task)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("RoleAuthorizer.Authorize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
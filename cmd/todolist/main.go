@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/winartodev/go-grpc/app"
+	"github.com/winartodev/go-grpc/config"
+)
+
+var (
+	configPath string
+	overrides  config.Overrides
+)
+
+func main() {
+	flag.StringVar(&configPath, "config", "", "YAML config file, or directory of profiles (default "+config.DefaultDir+", or $"+config.EnvPrefix+"CONFIG)")
+	flag.Var(&overrides, "set", "override a config field, e.g. -set database.host=db; may be repeated")
+	printConfig := flag.Bool("print-config", false, "print the resolved config, secrets redacted, and exit")
+	flag.Parse()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fatal("failed to load config", "error", err)
+	}
+
+	if *printConfig {
+		fmt.Print(cfg.String())
+		return
+	}
+
+	server, err := app.New(cfg, app.WithConfigReload(loadConfig))
+	if err != nil {
+		fatal("failed to start server", "error", err)
+	}
+
+	slog.SetDefault(server.Logger)
+	slog.Debug("config loaded", "config", cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.Run(ctx); err != nil {
+		fatal("server failed", "error", err)
+	}
+}
+
+func loadConfig() (*config.Config, error) {
+	var cfg config.Config
+	return cfg.GetConfig(configPath, overrides)
+}
+
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
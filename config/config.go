@@ -1,9 +1,15 @@
 package config
 
 import (
-	"io/ioutil"
-	"log"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/winartodev/go-grpc/database"
 	"gopkg.in/yaml.v2"
 )
 
@@ -11,6 +17,15 @@ type Config struct {
 	TodoList struct {
 		Host string `yaml:"host"`
 		Port string `yaml:"port"`
+
+		TLS struct {
+			// Insecure allows serving plaintext gRPC, for local development only.
+			Insecure     bool   `yaml:"insecure"`
+			CertFile     string `yaml:"cert_file"`
+			KeyFile      string `yaml:"key_file"`
+			ClientCAFile string `yaml:"client_ca_file"`
+			MinVersion   string `yaml:"min_version"`
+		} `yaml:"tls"`
 	} `yaml:"todolist"`
 
 	Database struct {
@@ -18,22 +33,310 @@ type Config struct {
 		Port     string `yaml:"port"`
 		Name     string `yaml:"name"`
 		Username string `yaml:"username"`
-		Password string `yaml:"password"`
+		Password string `yaml:"password" secret:"true"`
 		Driver   string `yaml:"driver"`
+
+		// PasswordFile names a file, such as a mounted secret, holding the password.
+		PasswordFile string `yaml:"password_file"`
+
+		// DSN, when set, is handed to the driver as is and the connection fields above and
+		// below are ignored.
+		DSN string `yaml:"dsn" secret:"true"`
+
+		// Timezone is the location times are stored in, UTC by default. TLS is a mode of the
+		// driver such as "true" or "skip-verify". Timeouts are durations such as "5s".
+		Timezone     string            `yaml:"timezone"`
+		TLS          string            `yaml:"tls"`
+		Charset      string            `yaml:"charset"`
+		Timeout      string            `yaml:"timeout"`
+		ReadTimeout  string            `yaml:"read_timeout"`
+		WriteTimeout string            `yaml:"write_timeout"`
+		Params       map[string]string `yaml:"params"`
+
+		// Pool sizes, zero keeps the database/sql default. ConnMaxLifetime is a duration
+		// such as "5m".
+		MaxOpenConns    int    `yaml:"max_open_conns"`
+		MaxIdleConns    int    `yaml:"max_idle_conns"`
+		ConnMaxLifetime string `yaml:"conn_max_lifetime"`
 	} `yaml:"database"`
+
+	Attachment struct {
+		MaxSize   int64  `yaml:"max_size"`
+		Storage   string `yaml:"storage"`
+		LocalPath string `yaml:"local_path"`
+	} `yaml:"attachment"`
+
+	Search struct {
+		Backend string `yaml:"backend"`
+	} `yaml:"search"`
+
+	Workflow struct {
+		Initial     string              `yaml:"initial"`
+		Done        string              `yaml:"done"`
+		Transitions map[string][]string `yaml:"transitions"`
+	} `yaml:"workflow"`
+
+	Auth struct {
+		Enabled      bool     `yaml:"enabled"`
+		HMACSecret   string   `yaml:"hmac_secret" secret:"true"`
+		RSAPublicKey string   `yaml:"rsa_public_key"`
+		JWKSFile     string   `yaml:"jwks_file"`
+		Issuer       string   `yaml:"issuer"`
+		Audience     string   `yaml:"audience"`
+		DefaultRole  string   `yaml:"default_role"`
+		Allowlist    []string `yaml:"allowlist"`
+
+		HMACSecretFile string `yaml:"hmac_secret_file"`
+	} `yaml:"auth"`
+
+	Log struct {
+		Level      string  `yaml:"level"`
+		Format     string  `yaml:"format"`
+		SampleRate float64 `yaml:"sample_rate"`
+	} `yaml:"log"`
+
+	Metrics struct {
+		Enabled bool   `yaml:"enabled"`
+		Address string `yaml:"address"`
+	} `yaml:"metrics"`
+
+	Gateway struct {
+		// Enabled serves the HTTP/JSON API on Address. AllowedOrigins are the origins
+		// browsers may call it from, "*" for any. MaxConnections caps the open connections,
+		// further clients wait until one is closed.
+		Enabled        bool     `yaml:"enabled"`
+		Address        string   `yaml:"address"`
+		AllowedOrigins []string `yaml:"allowed_origins"`
+		MaxConnections int      `yaml:"max_connections"`
+	} `yaml:"gateway"`
+
+	Health struct {
+		// ProbeInterval and ProbeTimeout are durations such as "10s".
+		ProbeInterval string `yaml:"probe_interval"`
+		ProbeTimeout  string `yaml:"probe_timeout"`
+	} `yaml:"health"`
+
+	Shutdown struct {
+		// DrainTimeout is how long in-flight calls get to finish on shutdown before they are
+		// cut off, a duration such as "30s".
+		DrainTimeout string `yaml:"drain_timeout"`
+		// PreDrainDelay is how long the server keeps taking new calls after reporting
+		// NOT_SERVING, so that load balancers notice before the listeners close. It comes on
+		// top of DrainTimeout and is off when empty.
+		PreDrainDelay string `yaml:"pre_drain_delay"`
+	} `yaml:"shutdown"`
+
+	Tracing struct {
+		Exporter    string `yaml:"exporter"`
+		Endpoint    string `yaml:"endpoint"`
+		ServiceName string `yaml:"service_name"`
+
+		// SampleRatio is the share of new traces recorded, from 0 for none to 1 for all.
+		// Traces continued from a caller follow its sampling decision.
+		SampleRatio float64 `yaml:"sample_ratio"`
+	} `yaml:"tracing"`
+
+	RateLimit struct {
+		// Enabled, the rates, the bursts and the quota can all be changed by a reload.
+		Enabled bool    `yaml:"enabled"`
+		Rate    float64 `yaml:"rate"`
+		Burst   int     `yaml:"burst"`
+		Methods map[string]struct {
+			Rate  float64 `yaml:"rate"`
+			Burst int     `yaml:"burst"`
+		} `yaml:"methods"`
+
+		// Quota caps the calls of each caller to all methods over Period, a duration such
+		// as "24h". Zero Requests means no quota. It is counted per server instance.
+		Quota struct {
+			Requests int    `yaml:"requests"`
+			Period   string `yaml:"period"`
+		} `yaml:"quota"`
+	} `yaml:"rate_limit"`
+
+	// sources are the files the config was read from, watched for hot reload.
+	sources []string
 }
 
-func (c *Config) GetConfig() *Config {
+// DefaultDir is read when neither -config nor TODO_CONFIG names a file or directory.
+const DefaultDir = "file"
+
+// Profiles are the values accepted in APP_ENV, each with a <profile>.yaml overlay next to
+// base.yaml. APP_ENV has no default: a deployment that forgot it must not silently run with
+// the insecure dev settings.
+var Profiles = []string{"dev", "staging", "prod", "test"}
+
+// GetConfig loads the configuration from path, a file or a directory of profiles, with in
+// increasing precedence: defaults, the YAML files, TODO_* environment variables and the
+// key=value overrides, then expands ${file:...} and ${env:...} references, reads the *_file
+// secrets and validates the result.
+func (c *Config) GetConfig(path string, overrides []string) (*Config, error) {
+	if err := c.Load(path, os.LookupEnv, overrides); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	return c, nil
+}
 
-	yamlFile, err := ioutil.ReadFile("file/dev.yaml")
+// Load fills c from the layers described in GetConfig. When path is a directory, its base.yaml
+// is read and then the overlay of the APP_ENV profile is merged over it. A missing default
+// directory is not an error, so the config can come from the environment alone.
+func (c *Config) Load(path string, lookupEnv func(string) (string, bool), overrides []string) error {
+	c.setDefaults()
+
+	if path == "" {
+		path, _ = lookupEnv(EnvPrefix + "CONFIG")
+	}
+
+	explicit := path != ""
+	if !explicit {
+		path = DefaultDir
+	}
+
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		err = c.loadProfile(path, lookupEnv)
+	case err == nil:
+		err = c.layer(path, func() error { return c.loadFile(path) })
+	case !explicit && errors.Is(err, fs.ErrNotExist):
+		err = nil
+	}
 	if err != nil {
-		log.Printf("yamlFile.Get err   #%v ", err)
+		return err
+	}
+
+	if err := c.layer("environment", func() error { return c.applyEnv(lookupEnv) }); err != nil {
+		return err
 	}
 
-	err = yaml.Unmarshal(yamlFile, c)
+	if err := c.layer("overrides", func() error { return c.applyOverrides(overrides) }); err != nil {
+		return err
+	}
+
+	if err := c.interpolate(lookupEnv); err != nil {
+		return err
+	}
+
+	return c.resolveSecretFiles()
+}
+
+// DSN builds the data source name of the database, or returns the raw one when set.
+func (c *Config) DSN() (string, error) {
+	if c.Database.DSN != "" {
+		return c.Database.DSN, nil
+	}
+
+	timeouts := make([]time.Duration, 3)
+	for i, value := range []string{c.Database.Timeout, c.Database.ReadTimeout, c.Database.WriteTimeout} {
+		if value == "" {
+			continue
+		}
+
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return "", err
+		}
+
+		timeouts[i] = timeout
+	}
+
+	return database.DSN(database.DSNConfig{
+		Driver:       c.Database.Driver,
+		Host:         c.Database.Host,
+		Port:         c.Database.Port,
+		Name:         c.Database.Name,
+		Username:     c.Database.Username,
+		Password:     c.Database.Password,
+		Timezone:     c.Database.Timezone,
+		TLS:          c.Database.TLS,
+		Charset:      c.Database.Charset,
+		Timeout:      timeouts[0],
+		ReadTimeout:  timeouts[1],
+		WriteTimeout: timeouts[2],
+		Params:       c.Database.Params,
+	})
+}
+
+func (c *Config) loadProfile(dir string, lookupEnv func(string) (string, bool)) error {
+	profile, _ := lookupEnv("APP_ENV")
+	if profile == "" {
+		return fmt.Errorf("APP_ENV is required to read the profiles in %s, expected one of %s", dir, strings.Join(Profiles, ", "))
+	}
+
+	if !isProfile(profile) {
+		return fmt.Errorf("APP_ENV: unknown profile %q, expected one of %s", profile, strings.Join(Profiles, ", "))
+	}
+
+	for _, path := range []string{filepath.Join(dir, "base.yaml"), filepath.Join(dir, profile+".yaml")} {
+		if err := c.layer(path, func() error { return c.loadFile(path) }); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadFile merges the file into c: keys present in the file replace the current values, nested
+// sections are merged key by key and everything else is left untouched.
+func (c *Config) loadFile(path string) error {
+	yamlFile, err := os.ReadFile(path)
 	if err != nil {
-		log.Fatalf("Unmarshal: %v", err)
+		return err
 	}
 
-	return c
+	// Unknown keys are rejected so that a typo is not silently ignored.
+	if err := yaml.UnmarshalStrict(yamlFile, c); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	c.sources = append(c.sources, path)
+
+	return nil
+}
+
+func isProfile(profile string) bool {
+	for _, p := range Profiles {
+		if p == profile {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setDefaults fills the fields that are still empty, so values already in c win.
+func (c *Config) setDefaults() {
+	setDefault(&c.TodoList.Host, "127.0.0.1")
+	setDefault(&c.TodoList.Port, "9000")
+	setDefault(&c.Database.Host, "127.0.0.1")
+	setDefault(&c.Database.Port, "3306")
+	setDefault(&c.Database.Driver, "mysql")
+	setDefault(&c.Database.Timezone, database.DefaultTimezone)
+	setDefault(&c.Attachment.MaxSize, 10<<20)
+	setDefault(&c.Attachment.Storage, "local")
+	setDefault(&c.Attachment.LocalPath, "data/attachments")
+	setDefault(&c.Search.Backend, "fulltext")
+	setDefault(&c.Auth.DefaultRole, "viewer")
+	setDefault(&c.Log.Level, "info")
+	setDefault(&c.Log.Format, "json")
+	setDefault(&c.Log.SampleRate, 1)
+	setDefault(&c.Metrics.Address, "127.0.0.1:9090")
+	setDefault(&c.Gateway.Address, "127.0.0.1:8080")
+	setDefault(&c.Gateway.MaxConnections, 1000)
+	setDefault(&c.Shutdown.DrainTimeout, "30s")
+	setDefault(&c.Tracing.Exporter, "none")
+	setDefault(&c.Tracing.ServiceName, "todolist")
+	setDefault(&c.Tracing.SampleRatio, 1)
+}
+
+func setDefault[T comparable](field *T, value T) {
+	var zero T
+	if *field == zero {
+		*field = value
+	}
 }
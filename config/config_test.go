@@ -1,7 +1,11 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -10,6 +14,14 @@ func TestConfig_GetConfig(t *testing.T) {
 		TodoList struct {
 			Host string `yaml:"host"`
 			Port string `yaml:"port"`
+
+			TLS struct {
+				Insecure     bool   `yaml:"insecure"`
+				CertFile     string `yaml:"cert_file"`
+				KeyFile      string `yaml:"key_file"`
+				ClientCAFile string `yaml:"client_ca_file"`
+				MinVersion   string `yaml:"min_version"`
+			} `yaml:"tls"`
 		} `yaml:"todolist"`
 
 		Database struct {
@@ -17,8 +29,24 @@ func TestConfig_GetConfig(t *testing.T) {
 			Port     string `yaml:"port"`
 			Name     string `yaml:"name"`
 			Username string `yaml:"username"`
-			Password string `yaml:"password"`
+			Password string `yaml:"password" secret:"true"`
 			Driver   string `yaml:"driver"`
+
+			PasswordFile string `yaml:"password_file"`
+
+			DSN string `yaml:"dsn" secret:"true"`
+
+			Timezone     string            `yaml:"timezone"`
+			TLS          string            `yaml:"tls"`
+			Charset      string            `yaml:"charset"`
+			Timeout      string            `yaml:"timeout"`
+			ReadTimeout  string            `yaml:"read_timeout"`
+			WriteTimeout string            `yaml:"write_timeout"`
+			Params       map[string]string `yaml:"params"`
+
+			MaxOpenConns    int    `yaml:"max_open_conns"`
+			MaxIdleConns    int    `yaml:"max_idle_conns"`
+			ConnMaxLifetime string `yaml:"conn_max_lifetime"`
 		} `yaml:"database"`
 	}
 
@@ -26,6 +54,14 @@ func TestConfig_GetConfig(t *testing.T) {
 		TodoList: struct {
 			Host string `yaml:"host"`
 			Port string `yaml:"port"`
+
+			TLS struct {
+				Insecure     bool   `yaml:"insecure"`
+				CertFile     string `yaml:"cert_file"`
+				KeyFile      string `yaml:"key_file"`
+				ClientCAFile string `yaml:"client_ca_file"`
+				MinVersion   string `yaml:"min_version"`
+			} `yaml:"tls"`
 		}{
 			Host: "127.0.0.1",
 			Port: "9000",
@@ -35,15 +71,31 @@ func TestConfig_GetConfig(t *testing.T) {
 			Port     string `yaml:"port"`
 			Name     string `yaml:"name"`
 			Username string `yaml:"username"`
-			Password string `yaml:"password"`
+			Password string `yaml:"password" secret:"true"`
 			Driver   string `yaml:"driver"`
+
+			PasswordFile string `yaml:"password_file"`
+
+			DSN string `yaml:"dsn" secret:"true"`
+
+			Timezone     string            `yaml:"timezone"`
+			TLS          string            `yaml:"tls"`
+			Charset      string            `yaml:"charset"`
+			Timeout      string            `yaml:"timeout"`
+			ReadTimeout  string            `yaml:"read_timeout"`
+			WriteTimeout string            `yaml:"write_timeout"`
+			Params       map[string]string `yaml:"params"`
+
+			MaxOpenConns    int    `yaml:"max_open_conns"`
+			MaxIdleConns    int    `yaml:"max_idle_conns"`
+			ConnMaxLifetime string `yaml:"conn_max_lifetime"`
 		}{
 			Host:     "127.0.0.1",
 			Port:     "3306",
 			Name:     "db",
 			Username: "username",
 			Password: "pasword",
-			Driver:   "driver",
+			Driver:   "mysql",
 		},
 	}
 
@@ -51,6 +103,14 @@ func TestConfig_GetConfig(t *testing.T) {
 		TodoList: struct {
 			Host string `yaml:"host"`
 			Port string `yaml:"port"`
+
+			TLS struct {
+				Insecure     bool   `yaml:"insecure"`
+				CertFile     string `yaml:"cert_file"`
+				KeyFile      string `yaml:"key_file"`
+				ClientCAFile string `yaml:"client_ca_file"`
+				MinVersion   string `yaml:"min_version"`
+			} `yaml:"tls"`
 		}{
 			Host: "127.0.0.1",
 			Port: "9000",
@@ -60,18 +120,53 @@ func TestConfig_GetConfig(t *testing.T) {
 			Port     string `yaml:"port"`
 			Name     string `yaml:"name"`
 			Username string `yaml:"username"`
-			Password string `yaml:"password"`
+			Password string `yaml:"password" secret:"true"`
 			Driver   string `yaml:"driver"`
+
+			PasswordFile string `yaml:"password_file"`
+
+			DSN string `yaml:"dsn" secret:"true"`
+
+			Timezone     string            `yaml:"timezone"`
+			TLS          string            `yaml:"tls"`
+			Charset      string            `yaml:"charset"`
+			Timeout      string            `yaml:"timeout"`
+			ReadTimeout  string            `yaml:"read_timeout"`
+			WriteTimeout string            `yaml:"write_timeout"`
+			Params       map[string]string `yaml:"params"`
+
+			MaxOpenConns    int    `yaml:"max_open_conns"`
+			MaxIdleConns    int    `yaml:"max_idle_conns"`
+			ConnMaxLifetime string `yaml:"conn_max_lifetime"`
 		}{
 			Host:     "127.0.0.1",
 			Port:     "3306",
 			Name:     "db",
 			Username: "username",
 			Password: "pasword",
-			Driver:   "driver",
+			Driver:   "mysql",
 		},
 	}
 
+	currentFields.TodoList.TLS.Insecure = true
+	wantFields.TodoList.TLS.Insecure = true
+	wantFields.Database.Timezone = "UTC"
+	wantFields.Attachment.MaxSize = 10 << 20
+	wantFields.Attachment.Storage = "local"
+	wantFields.Attachment.LocalPath = "data/attachments"
+	wantFields.Search.Backend = "fulltext"
+	wantFields.Auth.DefaultRole = "viewer"
+	wantFields.Log.Level = "info"
+	wantFields.Log.Format = "json"
+	wantFields.Log.SampleRate = 1
+	wantFields.Metrics.Address = "127.0.0.1:9090"
+	wantFields.Gateway.Address = "127.0.0.1:8080"
+	wantFields.Gateway.MaxConnections = 1000
+	wantFields.Shutdown.DrainTimeout = "30s"
+	wantFields.Tracing.Exporter = "none"
+	wantFields.Tracing.ServiceName = "todolist"
+	wantFields.Tracing.SampleRatio = 1
+
 	tests := []struct {
 		name   string
 		fields fields
@@ -89,9 +184,401 @@ func TestConfig_GetConfig(t *testing.T) {
 				TodoList: tt.fields.TodoList,
 				Database: tt.fields.Database,
 			}
-			if got := c.GetConfig(); !reflect.DeepEqual(got, tt.want) {
+			got, err := c.GetConfig("", nil)
+			if err != nil {
+				t.Fatalf("Config.GetConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Config.GetConfig() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestConfig_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte("database:\n  host: file-host\n  port: 3307\n  name: file-db\nauth:\n  enabled: false\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		path      string
+		env       map[string]string
+		overrides []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		check   func(c *Config) error
+		wantErr bool
+	}{
+		{
+			name: "File Over Defaults",
+			args: args{path: path},
+			check: func(c *Config) error {
+				if c.Database.Host != "file-host" || c.Database.Port != "3307" || c.Database.Driver != "mysql" {
+					return fmt.Errorf("database = %+v", c.Database)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Env Over File",
+			args: args{
+				path: path,
+				env: map[string]string{
+					"TODO_DATABASE_HOST":   "env-host",
+					"TODO_AUTH_ENABLED":    "true",
+					"TODO_AUTH_ALLOWLIST":  "/a, /b",
+					"TODO_LOG_SAMPLE_RATE": "0.5",
+				},
+			},
+			check: func(c *Config) error {
+				if c.Database.Host != "env-host" || c.Database.Name != "file-db" {
+					return fmt.Errorf("database = %+v", c.Database)
+				}
+				if !c.Auth.Enabled || !reflect.DeepEqual(c.Auth.Allowlist, []string{"/a", "/b"}) || c.Log.SampleRate != 0.5 {
+					return fmt.Errorf("auth = %+v, log = %+v", c.Auth, c.Log)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Flags Over Env",
+			args: args{
+				path:      path,
+				env:       map[string]string{"TODO_DATABASE_HOST": "env-host"},
+				overrides: []string{"database.host=flag-host", "todolist.tls.insecure=true"},
+			},
+			check: func(c *Config) error {
+				if c.Database.Host != "flag-host" || !c.TodoList.TLS.Insecure {
+					return fmt.Errorf("database = %+v, tls = %+v", c.Database, c.TodoList.TLS)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Config Path From Env",
+			args: args{env: map[string]string{"TODO_CONFIG": path}},
+			check: func(c *Config) error {
+				if c.Database.Host != "file-host" {
+					return fmt.Errorf("database = %+v", c.Database)
+				}
+				return nil
+			},
+		},
+		{
+			name:    "Missing Explicit File",
+			args:    args{path: filepath.Join(t.TempDir(), "missing.yaml")},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Env Value",
+			args:    args{path: path, env: map[string]string{"TODO_METRICS_ENABLED": "maybe"}},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Override",
+			args:    args{path: path, overrides: []string{"database.hostname=db"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookupEnv := func(key string) (string, bool) {
+				value, ok := tt.args.env[key]
+				return value, ok
+			}
+
+			c := &Config{}
+			err := c.Load(tt.args.path, lookupEnv, tt.args.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Config.Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := tt.check(c); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() *Config {
+		c := &Config{}
+		c.setDefaults()
+		c.TodoList.TLS.Insecure = true
+		c.Database.Name = "todo-db"
+		c.Database.Username = "root"
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(c *Config)
+		wantErrs []string
+	}{
+		{
+			name:   "Valid",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "All Problems At Once",
+			modify: func(c *Config) {
+				c.TodoList.Port = "70000"
+				c.Database.Driver = "postgres"
+				c.Database.Name = ""
+				c.Log.Level = "verbose"
+				c.Tracing.Exporter = "jaeger"
+			},
+			wantErrs: []string{"todolist.port", "database.driver", "database.name", "log.level", "tracing.exporter"},
+		},
+		{
+			name: "Missing TLS Files",
+			modify: func(c *Config) {
+				c.TodoList.TLS.Insecure = false
+				c.TodoList.TLS.CertFile = filepath.Join(t.TempDir(), "server.crt")
+			},
+			wantErrs: []string{"todolist.tls: cert_file and key_file must be set together", "todolist.tls.cert_file: stat"},
+		},
+		{
+			name: "No TLS Without Insecure",
+			modify: func(c *Config) {
+				c.TodoList.TLS.Insecure = false
+			},
+			wantErrs: []string{"todolist.tls.cert_file: is required"},
+		},
+		{
+			name: "Auth Without Key",
+			modify: func(c *Config) {
+				c.Auth.Enabled = true
+			},
+			wantErrs: []string{"auth: enabled but none of"},
+		},
+		{
+			name: "Rate Limit Without Rate",
+			modify: func(c *Config) {
+				c.RateLimit.Enabled = true
+				c.RateLimit.Burst = 10
+			},
+			wantErrs: []string{"rate_limit.rate"},
+		},
+		{
+			name: "Quota Without Period",
+			modify: func(c *Config) {
+				c.RateLimit.Enabled = true
+				c.RateLimit.Rate = 1
+				c.RateLimit.Burst = 10
+				c.RateLimit.Quota.Requests = 1000
+			},
+			wantErrs: []string{"rate_limit.quota.period: is required"},
+		},
+		{
+			name: "Public Gateway With Client Certificates",
+			modify: func(c *Config) {
+				c.TodoList.TLS.ClientCAFile = filepath.Join(t.TempDir(), "ca.crt")
+				c.Gateway.Enabled = true
+				c.Gateway.Address = "0.0.0.0:8080"
+			},
+			wantErrs: []string{"gateway.address: must be a loopback address"},
+		},
+		{
+			name: "Gateway Without Connection Cap",
+			modify: func(c *Config) {
+				c.Gateway.Enabled = true
+				c.Gateway.MaxConnections = -1
+			},
+			wantErrs: []string{"gateway.max_connections: must be positive, got -1"},
+		},
+		{
+			name: "Invalid Workflow",
+			modify: func(c *Config) {
+				c.Workflow.Transitions = map[string][]string{"todo": {"done"}}
+			},
+			wantErrs: []string{"workflow.initial: is required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(c)
+
+			err := c.Validate()
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("Config.Validate() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Config.Validate() error = nil, want %v", tt.wantErrs)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Config.Validate() error = %v, want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_Load_RejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("database:\n  hostname: db\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Config{}
+	if err := c.Load(path, func(string) (string, bool) { return "", false }, nil); err == nil || !strings.Contains(err.Error(), "hostname") {
+		t.Errorf("Config.Load() error = %v, want the unknown key reported", err)
+	}
+}
+
+func TestConfig_Load_Profiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"base.yaml":    "todolist:\n  port: 9000\n  tls:\n    min_version: \"1.2\"\ndatabase:\n  host: base-host\n  name: todo-db\n",
+		"staging.yaml": "todolist:\n  tls:\n    insecure: true\ndatabase:\n  host: staging-host\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		appEnv  string
+		check   func(c *Config) error
+		wantErr bool
+	}{
+		{
+			name:   "Overlay Merged Deeply",
+			dir:    dir,
+			appEnv: "staging",
+			check: func(c *Config) error {
+				if c.Database.Host != "staging-host" || c.Database.Name != "todo-db" {
+					return fmt.Errorf("database = %+v", c.Database)
+				}
+				if !c.TodoList.TLS.Insecure || c.TodoList.TLS.MinVersion != "1.2" || c.TodoList.Port != "9000" {
+					return fmt.Errorf("todolist = %+v", c.TodoList)
+				}
+				return nil
+			},
+		},
+		{
+			name:    "Missing Overlay",
+			dir:     dir,
+			appEnv:  "prod",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Profile",
+			dir:     dir,
+			appEnv:  "qa",
+			wantErr: true,
+		},
+		{
+			name:    "Missing Profile",
+			dir:     dir,
+			appEnv:  "",
+			wantErr: true,
+		},
+		{
+			name:   "Shipped Dev Profile",
+			dir:    filepath.Join("..", DefaultDir),
+			appEnv: "dev",
+			check: func(c *Config) error {
+				if c.Database.Host != "127.0.0.1" || c.Database.Name != "todo-db" || !c.TodoList.TLS.Insecure {
+					return fmt.Errorf("database = %+v, tls = %+v", c.Database, c.TodoList.TLS)
+				}
+				return c.Validate()
+			},
+		},
+		{
+			name:   "Shipped Test Profile",
+			dir:    filepath.Join("..", DefaultDir),
+			appEnv: "test",
+			check: func(c *Config) error {
+				if c.Database.Name != "todo-test" || c.Metrics.Enabled {
+					return fmt.Errorf("database = %+v, metrics = %+v", c.Database, c.Metrics)
+				}
+				return c.Validate()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookupEnv := func(key string) (string, bool) {
+				if key == "APP_ENV" && tt.appEnv != "" {
+					return tt.appEnv, true
+				}
+				return "", false
+			}
+
+			c := &Config{}
+			err := c.Load(tt.dir, lookupEnv, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Config.Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := tt.check(c); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_DSN(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Built",
+			modify: func(c *Config) {
+				c.Database.Timeout = "5s"
+			},
+			want: "root:root@tcp(127.0.0.1:3306)/todo-db?parseTime=true&timeout=5s",
+		},
+		{
+			name: "Raw",
+			modify: func(c *Config) {
+				c.Database.DSN = "app:secret@unix(/run/mysqld/mysqld.sock)/todo?parseTime=true"
+			},
+			want: "app:secret@unix(/run/mysqld/mysqld.sock)/todo?parseTime=true",
+		},
+		{
+			name: "Invalid Timeout",
+			modify: func(c *Config) {
+				c.Database.Timeout = "soon"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{}
+			c.setDefaults()
+			c.Database.Name = "todo-db"
+			c.Database.Username = "root"
+			c.Database.Password = "root"
+			tt.modify(c)
+
+			got, err := c.DSN()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Config.DSN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Config.DSN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix starts the name of every environment variable overriding a config field, e.g.
+// TODO_DATABASE_PASSWORD for database.password.
+const EnvPrefix = "TODO_"
+
+// field is a settable leaf of Config, named by the dotted path of its yaml keys.
+type field struct {
+	key    string
+	value  reflect.Value
+	secret bool
+}
+
+// envName returns the environment variable overriding f.
+func (f field) envName() string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(f.key, ".", "_"))
+}
+
+// fields lists the leaves of c that can be overridden. Maps, such as the workflow transitions,
+// can only be set in the file.
+func (c *Config) fields() []field {
+	var fields []field
+	for _, f := range c.allFields() {
+		if f.value.Kind() != reflect.Map {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// allFields lists every leaf of c, maps included.
+func (c *Config) allFields() []field {
+	return collectFields(reflect.ValueOf(c).Elem(), "")
+}
+
+func collectFields(v reflect.Value, prefix string) []field {
+	var fields []field
+	for i := 0; i < v.NumField(); i++ {
+		tag := v.Type().Field(i).Tag
+		name := strings.Split(tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		key := prefix + name
+		value := v.Field(i)
+		switch value.Kind() {
+		case reflect.Struct:
+			fields = append(fields, collectFields(value, key+".")...)
+		default:
+			fields = append(fields, field{key: key, value: value, secret: tag.Get("secret") == "true"})
+		}
+	}
+
+	return fields
+}
+
+// set parses raw into the field according to its type. Lists are comma separated.
+func (f field) set(raw string) error {
+	switch f.value.Kind() {
+	case reflect.String:
+		f.value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+		f.value.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+		f.value.SetInt(n)
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+		f.value.SetFloat(n)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		f.value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("%s: unsupported type %s", f.key, f.value.Type())
+	}
+
+	return nil
+}
+
+// applyEnv overrides every field whose environment variable is set.
+func (c *Config) applyEnv(lookupEnv func(string) (string, bool)) error {
+	for _, f := range c.fields() {
+		if raw, ok := lookupEnv(f.envName()); ok {
+			if err := f.set(raw); err != nil {
+				return fmt.Errorf("%s: %w", f.envName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyOverrides sets the fields named in key=value overrides, as given with -set.
+func (c *Config) applyOverrides(overrides []string) error {
+	fields := make(map[string]field)
+	for _, f := range c.fields() {
+		fields[f.key] = f
+	}
+
+	for _, override := range overrides {
+		key, raw, ok := strings.Cut(override, "=")
+		if !ok {
+			return fmt.Errorf("override %q is not key=value", override)
+		}
+
+		f, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("override %q: unknown config key %s", override, key)
+		}
+
+		if err := f.set(raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Overrides is a flag.Value collecting repeated key=value overrides, such as a -set flag.
+type Overrides []string
+
+func (o *Overrides) String() string {
+	return strings.Join(*o, " ")
+}
+
+func (o *Overrides) Set(value string) error {
+	*o = append(*o, value)
+	return nil
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is how often the config files are checked for changes.
+const DefaultWatchInterval = 5 * time.Second
+
+// Reloadable lists the keys that take effect without a restart. A change to any other key,
+// such as the listen address, is logged and ignored until the next restart.
+var Reloadable = []string{
+	"log.level",
+	"rate_limit.enabled",
+	"rate_limit.rate",
+	"rate_limit.burst",
+	"rate_limit.methods",
+	"rate_limit.quota.requests",
+	"rate_limit.quota.period",
+	"database.max_open_conns",
+	"database.max_idle_conns",
+	"database.conn_max_lifetime",
+}
+
+// Watcher reloads the config on demand, typically on SIGHUP, or when one of its files changes,
+// and hands the result to Apply along with the keys that changed.
+type Watcher struct {
+	Load  func() (*Config, error)
+	Apply func(c *Config, changed []string)
+
+	mu       sync.Mutex
+	current  *Config
+	modTimes map[string]time.Time
+}
+
+func NewWatcher(current *Config, load func() (*Config, error), apply func(c *Config, changed []string)) *Watcher {
+	return &Watcher{
+		Load:     load,
+		Apply:    apply,
+		current:  current,
+		modTimes: statSources(current.sources),
+	}
+}
+
+// Current returns the config in effect.
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.current
+}
+
+// Reload loads the config again and applies the changes to reloadable keys. Other changes are
+// reverted, so the config in effect only ever differs from the startup one in reloadable keys.
+// When the new config fails to load or validate, the current one stays in effect.
+func (w *Watcher) Reload() error {
+	next, err := w.Load()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err != nil {
+		// The broken files are not retried until they change again.
+		w.modTimes = statSources(w.current.sources)
+		return err
+	}
+
+	merged := *w.current
+	nextFields := make(map[string]field)
+	for _, f := range next.allFields() {
+		nextFields[f.key] = f
+	}
+
+	var applied []string
+	for _, f := range merged.allFields() {
+		nextValue := nextFields[f.key].value
+		if reflect.DeepEqual(f.value.Interface(), nextValue.Interface()) {
+			continue
+		}
+
+		if !isReloadable(f.key) {
+			slog.Warn("config change needs a restart, ignored", "key", f.key)
+			continue
+		}
+
+		f.value.Set(nextValue)
+		applied = append(applied, f.key)
+	}
+
+	merged.sources = next.sources
+	w.current = &merged
+	w.modTimes = statSources(next.sources)
+
+	slog.Info("config reloaded", "applied", applied)
+	w.Apply(w.current, applied)
+
+	return nil
+}
+
+// Changed reports whether any of the config files was modified since the last load.
+func (w *Watcher) Changed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for path, modTime := range statSources(w.current.sources) {
+		if !modTime.Equal(w.modTimes[path]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Watch reloads on every value received from signals and, every interval, when the files
+// changed, until ctx is done.
+func (w *Watcher) Watch(ctx context.Context, interval time.Duration, signals <-chan os.Signal) {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+		case <-ticker.C:
+			if !w.Changed() {
+				continue
+			}
+		}
+
+		if err := w.Reload(); err != nil {
+			slog.Error("config reload failed, keeping the current config", "error", err)
+		}
+	}
+}
+
+func statSources(sources []string) map[string]time.Time {
+	modTimes := make(map[string]time.Time, len(sources))
+	for _, path := range sources {
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+
+	return modTimes
+}
+
+func isReloadable(key string) bool {
+	for _, k := range Reloadable {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
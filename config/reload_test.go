@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	load := func() (*Config, error) {
+		c := &Config{}
+		if err := c.Load(path, func(string) (string, bool) { return "", false }, nil); err != nil {
+			return nil, err
+		}
+		return c, c.Validate()
+	}
+
+	write("todolist:\n  port: 9000\n  tls:\n    insecure: true\ndatabase:\n  name: todo-db\n  username: root\nlog:\n  level: info\n")
+	current, err := load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var applied []*Config
+	watcher := NewWatcher(current, load, func(c *Config, _ []string) {
+		applied = append(applied, c)
+	})
+
+	tests := []struct {
+		name     string
+		content  string
+		wantErr  bool
+		wantPort string
+		wantLog  string
+		wantRate float64
+	}{
+		{
+			name:     "Reloadable Applied, Immutable Ignored",
+			content:  "todolist:\n  port: 9100\n  tls:\n    insecure: true\ndatabase:\n  name: todo-db\n  username: root\nlog:\n  level: debug\nrate_limit:\n  enabled: true\n  rate: 5\n  burst: 10\n",
+			wantPort: "9000",
+			wantLog:  "debug",
+			wantRate: 5,
+		},
+		{
+			name:     "Invalid Config Kept Out",
+			content:  "todolist:\n  port: 9000\n  tls:\n    insecure: true\ndatabase:\n  name: todo-db\n  username: root\nlog:\n  level: loud\n",
+			wantErr:  true,
+			wantPort: "9000",
+			wantLog:  "debug",
+			wantRate: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			write(tt.content)
+			applied = nil
+
+			err := watcher.Reload()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Watcher.Reload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == (len(applied) == 1) {
+				t.Errorf("Apply called %d times, wantErr %v", len(applied), tt.wantErr)
+			}
+
+			got := watcher.Current()
+			if got.TodoList.Port != tt.wantPort || got.Log.Level != tt.wantLog || got.RateLimit.Rate != tt.wantRate {
+				t.Errorf("Current() port = %s, log level = %s, rate = %v, want %s, %s, %v", got.TodoList.Port, got.Log.Level, got.RateLimit.Rate, tt.wantPort, tt.wantLog, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestWatcher_Changed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("log:\n  level: info\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	current := &Config{}
+	if err := current.Load(path, func(string) (string, bool) { return "", false }, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher := NewWatcher(current, func() (*Config, error) { return nil, errors.New("unused") }, func(*Config, []string) {})
+	if watcher.Changed() {
+		t.Fatal("Changed() = true before the file was touched")
+	}
+
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if !watcher.Changed() {
+		t.Error("Changed() = false after the file was modified")
+	}
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// RedactedValue replaces the value of secret fields, marked with the secret:"true" tag, whenever
+// the config is printed or logged.
+const RedactedValue = "[REDACTED]"
+
+// reference matches ${file:/path} and ${env:NAME} in string values.
+var reference = regexp.MustCompile(`\$\{(file|env):([^}]+)\}`)
+
+// interpolate expands the references in every string field, so that any value can be read from
+// a mounted file or an environment variable. File contents lose their trailing newline.
+func (c *Config) interpolate(lookupEnv func(string) (string, bool)) error {
+	var errs []string
+	expand := func(key string, raw string) string {
+		return reference.ReplaceAllStringFunc(raw, func(ref string) string {
+			match := reference.FindStringSubmatch(ref)
+			value, err := resolveReference(match[1], match[2], lookupEnv)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+			}
+			return value
+		})
+	}
+
+	for _, f := range c.fields() {
+		switch f.value.Kind() {
+		case reflect.String:
+			f.value.SetString(expand(f.key, f.value.String()))
+		case reflect.Slice:
+			for i := 0; i < f.value.Len(); i++ {
+				item := f.value.Index(i)
+				item.SetString(expand(f.key, item.String()))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func resolveReference(kind string, name string, lookupEnv func(string) (string, bool)) (string, error) {
+	switch kind {
+	case "env":
+		value, ok := lookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	default:
+		return readSecretFile(name)
+	}
+}
+
+// secret is a secret field together with the *_file field that can stand for it.
+type secret struct {
+	key   string
+	value *string
+	file  *string
+}
+
+func (c *Config) secrets() []secret {
+	return []secret{
+		{"database.password", &c.Database.Password, &c.Database.PasswordFile},
+		{"auth.hmac_secret", &c.Auth.HMACSecret, &c.Auth.HMACSecretFile},
+	}
+}
+
+// layer applies one config layer. A secret or *_file set by the layer replaces whichever of the
+// two a lower layer set, so that TODO_DATABASE_PASSWORD wins over a password_file from YAML.
+// Setting both in the same layer is ambiguous and rejected.
+func (c *Config) layer(name string, apply func() error) error {
+	secrets := c.secrets()
+	before := make([][2]string, len(secrets))
+	for i, secret := range secrets {
+		before[i] = [2]string{*secret.value, *secret.file}
+	}
+
+	if err := apply(); err != nil {
+		return err
+	}
+
+	for i, secret := range secrets {
+		valueSet := *secret.value != "" && *secret.value != before[i][0]
+		fileSet := *secret.file != "" && *secret.file != before[i][1]
+		switch {
+		case valueSet && fileSet:
+			return fmt.Errorf("%s: %s and %s_file are both set", name, secret.key, secret.key)
+		case valueSet:
+			*secret.file = ""
+		case fileSet:
+			*secret.value = ""
+		}
+	}
+
+	return nil
+}
+
+// resolveSecretFiles reads the *_file fields into the secret they stand for. After layer, at
+// most one of the two is set.
+func (c *Config) resolveSecretFiles() error {
+	for _, secret := range c.secrets() {
+		if *secret.file == "" {
+			continue
+		}
+
+		if *secret.value != "" {
+			return fmt.Errorf("%s and %s_file are both set", secret.key, secret.key)
+		}
+
+		value, err := readSecretFile(*secret.file)
+		if err != nil {
+			return fmt.Errorf("%s_file: %w", secret.key, err)
+		}
+
+		*secret.value = value
+	}
+
+	return nil
+}
+
+func readSecretFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// Redacted returns a copy of c with every non-empty secret replaced by RedactedValue.
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	for _, f := range redacted.fields() {
+		if f.secret && f.value.String() != "" {
+			f.value.SetString(RedactedValue)
+		}
+	}
+
+	return &redacted
+}
+
+// String renders the redacted config as YAML.
+func (c *Config) String() string {
+	out, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("config: %v", err)
+	}
+
+	return string(out)
+}
+
+// LogValue keeps secrets out of the logs when the config is passed to slog.
+func (c *Config) LogValue() slog.Value {
+	return slog.StringValue(c.String())
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Load_Secrets(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "db-password")
+	if err := os.WriteFile(passwordFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		yaml         string
+		env          map[string]string
+		overrides    []string
+		wantHost     string
+		wantPassword string
+		wantErr      bool
+	}{
+		{
+			name:         "Password File",
+			overrides:    []string{"database.password_file=" + passwordFile},
+			wantHost:     "127.0.0.1",
+			wantPassword: "s3cret",
+		},
+		{
+			name:         "Interpolation",
+			env:          map[string]string{"DB_HOST": "mysql.internal"},
+			overrides:    []string{"database.host=${env:DB_HOST}", "database.password=${file:" + passwordFile + "}"},
+			wantHost:     "mysql.internal",
+			wantPassword: "s3cret",
+		},
+		{
+			name:      "Password And File",
+			overrides: []string{"database.password=root", "database.password_file=" + passwordFile},
+			wantErr:   true,
+		},
+		{
+			name:    "Password And File In One File",
+			yaml:    "database:\n  password: root\n  password_file: " + passwordFile + "\n",
+			wantErr: true,
+		},
+		{
+			name:         "Env Password Wins Over File In YAML",
+			yaml:         "database:\n  password_file: " + passwordFile + "\n",
+			env:          map[string]string{"TODO_DATABASE_PASSWORD": "root"},
+			wantHost:     "127.0.0.1",
+			wantPassword: "root",
+		},
+		{
+			name:         "Override File Wins Over Password In Env",
+			env:          map[string]string{"TODO_DATABASE_PASSWORD": "root"},
+			overrides:    []string{"database.password_file=" + passwordFile},
+			wantHost:     "127.0.0.1",
+			wantPassword: "s3cret",
+		},
+		{
+			name:      "Unset Env Reference",
+			overrides: []string{"database.host=${env:MISSING}"},
+			wantErr:   true,
+		},
+		{
+			name:      "Missing File Reference",
+			overrides: []string{"database.password=${file:" + filepath.Join(dir, "missing") + "}"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configFile, []byte(tt.yaml), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			lookupEnv := func(key string) (string, bool) {
+				value, ok := tt.env[key]
+				return value, ok
+			}
+
+			c := &Config{}
+			err := c.Load(configFile, lookupEnv, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Config.Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if c.Database.Host != tt.wantHost || c.Database.Password != tt.wantPassword {
+				t.Errorf("database = %s / %s, want %s / %s", c.Database.Host, c.Database.Password, tt.wantHost, tt.wantPassword)
+			}
+		})
+	}
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	c := &Config{}
+	c.Database.Username = "root"
+	c.Database.Password = "s3cret"
+	c.Auth.HMACSecret = "hmac-s3cret"
+
+	redacted := c.Redacted()
+	if redacted.Database.Password != RedactedValue || redacted.Auth.HMACSecret != RedactedValue || redacted.Database.Username != "root" {
+		t.Errorf("Config.Redacted() = %+v, %+v", redacted.Database, redacted.Auth)
+	}
+	if c.Database.Password != "s3cret" {
+		t.Errorf("Config.Redacted() modified the original config")
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("config loaded", "config", c)
+
+	for name, out := range map[string]string{"String": c.String(), "LogValue": buf.String()} {
+		if strings.Contains(out, "s3cret") || !strings.Contains(out, RedactedValue) {
+			t.Errorf("%s leaks a secret: %s", name, out)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/winartodev/go-grpc/authz"
+	"github.com/winartodev/go-grpc/logging"
+	"github.com/winartodev/go-grpc/tlsconfig"
+	"github.com/winartodev/go-grpc/workflow"
+)
+
+// Validate checks the configuration as a whole and reports every problem found, one per line,
+// each prefixed with the yaml key at fault.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.required("todolist.port", c.TodoList.Port)
+	v.listenPort("todolist.port", c.TodoList.Port)
+
+	tls := c.TodoList.TLS
+	if !tls.Insecure && tls.CertFile == "" {
+		v.addf("todolist.tls.cert_file", "is required unless todolist.tls.insecure is set")
+	}
+	if (tls.CertFile == "") != (tls.KeyFile == "") {
+		v.addf("todolist.tls", "cert_file and key_file must be set together")
+	}
+	if tls.ClientCAFile != "" && tls.CertFile == "" {
+		v.addf("todolist.tls.client_ca_file", "requires cert_file, client certificates are only checked over TLS")
+	}
+	v.fileExists("todolist.tls.cert_file", tls.CertFile)
+	v.fileExists("todolist.tls.key_file", tls.KeyFile)
+	v.fileExists("todolist.tls.client_ca_file", tls.ClientCAFile)
+	if _, err := tlsconfig.ParseMinVersion(tls.MinVersion); err != nil {
+		v.add("todolist.tls.min_version", err)
+	}
+
+	v.oneOf("database.driver", c.Database.Driver, "mysql")
+	if c.Database.DSN == "" {
+		v.required("database.host", c.Database.Host)
+		v.required("database.name", c.Database.Name)
+		v.required("database.username", c.Database.Username)
+		v.port("database.port", c.Database.Port)
+	}
+	if _, err := time.LoadLocation(c.Database.Timezone); err != nil {
+		v.add("database.timezone", err)
+	}
+	v.duration("database.timeout", c.Database.Timeout)
+	v.duration("database.read_timeout", c.Database.ReadTimeout)
+	v.duration("database.write_timeout", c.Database.WriteTimeout)
+	if c.Database.MaxOpenConns < 0 {
+		v.addf("database.max_open_conns", "must not be negative, got %d", c.Database.MaxOpenConns)
+	}
+	if c.Database.MaxIdleConns < 0 {
+		v.addf("database.max_idle_conns", "must not be negative, got %d", c.Database.MaxIdleConns)
+	}
+	v.duration("database.conn_max_lifetime", c.Database.ConnMaxLifetime)
+
+	if c.Attachment.MaxSize <= 0 {
+		v.addf("attachment.max_size", "must be positive, got %d", c.Attachment.MaxSize)
+	}
+	v.oneOf("attachment.storage", c.Attachment.Storage, "", "local")
+	if c.Attachment.Storage == "" || c.Attachment.Storage == "local" {
+		v.required("attachment.local_path", c.Attachment.LocalPath)
+	}
+
+	v.oneOf("search.backend", c.Search.Backend, "", "fulltext", "index")
+
+	if c.Workflow.Initial != "" {
+		if _, err := workflow.New(c.Workflow.Initial, c.Workflow.Done, c.Workflow.Transitions); err != nil {
+			v.add("workflow", err)
+		}
+	} else if len(c.Workflow.Transitions) > 0 {
+		v.addf("workflow.initial", "is required when transitions are set")
+	}
+
+	if c.Auth.Enabled {
+		if c.Auth.HMACSecret == "" && c.Auth.RSAPublicKey == "" && c.Auth.JWKSFile == "" {
+			v.addf("auth", "enabled but none of hmac_secret, rsa_public_key or jwks_file is set")
+		}
+		v.fileExists("auth.rsa_public_key", c.Auth.RSAPublicKey)
+		v.fileExists("auth.jwks_file", c.Auth.JWKSFile)
+	}
+	v.oneOf("auth.default_role", c.Auth.DefaultRole, "", authz.RoleViewer, authz.RoleEditor, authz.RoleAdmin)
+
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		v.add("log.level", err)
+	}
+	v.oneOf("log.format", strings.ToLower(c.Log.Format), "", "json", "text")
+	v.ratio("log.sample_rate", c.Log.SampleRate)
+
+	if c.Metrics.Enabled {
+		v.required("metrics.address", c.Metrics.Address)
+	}
+
+	if c.Gateway.Enabled {
+		v.required("gateway.address", c.Gateway.Address)
+		if c.Gateway.MaxConnections <= 0 {
+			v.addf("gateway.max_connections", "must be positive, got %d", c.Gateway.MaxConnections)
+		}
+		if tls.ClientCAFile != "" && c.Gateway.Address != "" && !isLoopback(c.Gateway.Address) {
+			v.addf("gateway.address", "must be a loopback address while todolist.tls.client_ca_file is set, the plain HTTP gateway would skip client certificates")
+		}
+	}
+
+	v.duration("health.probe_interval", c.Health.ProbeInterval)
+	v.duration("health.probe_timeout", c.Health.ProbeTimeout)
+	v.duration("shutdown.drain_timeout", c.Shutdown.DrainTimeout)
+	v.duration("shutdown.pre_drain_delay", c.Shutdown.PreDrainDelay)
+
+	v.oneOf("tracing.exporter", c.Tracing.Exporter, "", "none", "stdout", "otlp")
+	v.ratio("tracing.sample_ratio", c.Tracing.SampleRatio)
+
+	if c.RateLimit.Enabled {
+		v.rate("rate_limit", c.RateLimit.Rate, c.RateLimit.Burst)
+		if c.RateLimit.Quota.Requests < 0 {
+			v.addf("rate_limit.quota.requests", "must not be negative, got %d", c.RateLimit.Quota.Requests)
+		}
+		if c.RateLimit.Quota.Requests > 0 {
+			v.required("rate_limit.quota.period", c.RateLimit.Quota.Period)
+			v.duration("rate_limit.quota.period", c.RateLimit.Quota.Period)
+		}
+		for method, limit := range c.RateLimit.Methods {
+			v.rate("rate_limit.methods."+method, limit.Rate, limit.Burst)
+		}
+	}
+
+	return errors.Join(v.errs...)
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) add(key string, err error) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %w", key, err))
+}
+
+func (v *validator) addf(key string, format string, args ...interface{}) {
+	v.add(key, fmt.Errorf(format, args...))
+}
+
+func (v *validator) required(key string, value string) {
+	if value == "" {
+		v.addf(key, "is required")
+	}
+}
+
+func (v *validator) port(key string, value string) {
+	if value == "" {
+		return
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		v.addf(key, "must be a port between 1 and 65535, got %q", value)
+	}
+}
+
+// listenPort is port where 0 is also accepted, asking the system for a free port.
+func (v *validator) listenPort(key string, value string) {
+	if value != "0" {
+		v.port(key, value)
+	}
+}
+
+func (v *validator) oneOf(key string, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+
+	var known []string
+	for _, a := range allowed {
+		if a != "" {
+			known = append(known, a)
+		}
+	}
+
+	v.addf(key, "unknown value %q, expected one of %s", value, strings.Join(known, ", "))
+}
+
+func (v *validator) fileExists(key string, path string) {
+	if path == "" {
+		return
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		v.add(key, err)
+	}
+}
+
+func (v *validator) ratio(key string, value float64) {
+	if value < 0 || value > 1 {
+		v.addf(key, "must be between 0 and 1, got %v", value)
+	}
+}
+
+func (v *validator) duration(key string, value string) {
+	if value == "" {
+		return
+	}
+
+	if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		v.addf(key, "must be a positive duration such as 10s, got %q", value)
+	}
+}
+
+func (v *validator) rate(key string, rate float64, burst int) {
+	if rate <= 0 {
+		v.addf(key+".rate", "must be positive, got %v", rate)
+	}
+	if burst <= 0 {
+		v.addf(key+".burst", "must be positive, got %d", burst)
+	}
+}
+
+// isLoopback reports whether address, a host:port, only listens on the local machine. An empty
+// host listens on every interface.
+func isLoopback(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,85 @@
+// Package database builds the data source name handed to sql.Open.
+package database
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// DefaultTimezone is the location times are stored in when none is configured, so that the
+// stored values do not depend on where the server is deployed.
+const DefaultTimezone = "UTC"
+
+// DSNConfig describes a connection. Timeouts left at zero use the driver default, and Params are
+// extra driver specific parameters.
+type DSNConfig struct {
+	Driver   string
+	Host     string
+	Port     string
+	Name     string
+	Username string
+	Password string
+
+	Timezone     string
+	TLS          string
+	Charset      string
+	Timeout      time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	Params       map[string]string
+}
+
+// DSN builds the data source name of cfg for its driver.
+func DSN(cfg DSNConfig) (string, error) {
+	switch cfg.Driver {
+	case "mysql":
+		return mysqlDSN(cfg)
+	default:
+		return "", fmt.Errorf("unknown database driver %q", cfg.Driver)
+	}
+}
+
+// mysqlDSN always sets parseTime, so DATETIME columns scan into time.Time, and loc, the
+// location times are written in and read back as. TLS is one of true, false, skip-verify,
+// preferred or the name of a config registered with mysql.RegisterTLSConfig.
+func mysqlDSN(cfg DSNConfig) (string, error) {
+	timezone := cfg.Timezone
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", fmt.Errorf("timezone: %w", err)
+	}
+
+	mysqlConfig := mysql.NewConfig()
+	mysqlConfig.User = cfg.Username
+	mysqlConfig.Passwd = cfg.Password
+	mysqlConfig.Net = "tcp"
+	mysqlConfig.Addr = net.JoinHostPort(cfg.Host, cfg.Port)
+	mysqlConfig.DBName = cfg.Name
+	mysqlConfig.ParseTime = true
+	mysqlConfig.Loc = loc
+	mysqlConfig.TLSConfig = cfg.TLS
+	mysqlConfig.Timeout = cfg.Timeout
+	mysqlConfig.ReadTimeout = cfg.ReadTimeout
+	mysqlConfig.WriteTimeout = cfg.WriteTimeout
+
+	if cfg.Charset != "" || len(cfg.Params) > 0 {
+		mysqlConfig.Params = make(map[string]string)
+	}
+
+	for key, value := range cfg.Params {
+		mysqlConfig.Params[key] = value
+	}
+
+	if cfg.Charset != "" {
+		mysqlConfig.Params["charset"] = cfg.Charset
+	}
+
+	return mysqlConfig.FormatDSN(), nil
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestDSN(t *testing.T) {
+	base := DSNConfig{
+		Driver:   "mysql",
+		Host:     "127.0.0.1",
+		Port:     "3306",
+		Name:     "todo-db",
+		Username: "root",
+		Password: "p@ss:word/",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *DSNConfig)
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Defaults To UTC",
+			modify: func(cfg *DSNConfig) {},
+			want:   "root:p@ss:word/@tcp(127.0.0.1:3306)/todo-db?parseTime=true",
+		},
+		{
+			name: "All Params",
+			modify: func(cfg *DSNConfig) {
+				cfg.Timezone = "Asia/Jakarta"
+				cfg.TLS = "skip-verify"
+				cfg.Charset = "utf8mb4"
+				cfg.Timeout = 5 * time.Second
+				cfg.ReadTimeout = 30 * time.Second
+				cfg.WriteTimeout = 30 * time.Second
+				cfg.Params = map[string]string{"time_zone": "'+00:00'"}
+			},
+			want: "root:p@ss:word/@tcp(127.0.0.1:3306)/todo-db?loc=Asia%2FJakarta&parseTime=true&readTimeout=30s&timeout=5s&tls=skip-verify&writeTimeout=30s&charset=utf8mb4&time_zone=%27%2B00%3A00%27",
+		},
+		{
+			name: "Unknown Timezone",
+			modify: func(cfg *DSNConfig) {
+				cfg.Timezone = "Mars/Olympus"
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown Driver",
+			modify: func(cfg *DSNConfig) {
+				cfg.Driver = "oracle"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			tt.modify(&cfg)
+
+			got, err := DSN(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DSN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("DSN() = %v, want %v", got, tt.want)
+			}
+
+			parsed, err := mysql.ParseDSN(got)
+			if err != nil {
+				t.Fatalf("mysql.ParseDSN(%q) error = %v", got, err)
+			}
+			if parsed.Passwd != base.Password || !parsed.ParseTime {
+				t.Errorf("mysql.ParseDSN() = %+v, want the password and parseTime kept", parsed)
+			}
+		})
+	}
+}
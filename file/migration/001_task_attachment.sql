@@ -0,0 +1,15 @@
+-- Adds the attachments of tasks. The scripts start from the original task table, with only
+-- id, description, complete, created_at and updated_at.
+CREATE TABLE IF NOT EXISTS task_attachment (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    task_id      BIGINT       NOT NULL,
+    name         VARCHAR(255) NOT NULL,
+    size         BIGINT       NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    sha256       CHAR(64)     NOT NULL,
+    storage_key  VARCHAR(255) NOT NULL,
+    created_at   DATETIME     NOT NULL,
+    PRIMARY KEY (id),
+    KEY idx_task_attachment_task_id (task_id),
+    CONSTRAINT fk_task_attachment_task FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE
+);
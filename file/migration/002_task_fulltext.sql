@@ -0,0 +1,2 @@
+-- Adds the full-text index used by search.backend fulltext.
+ALTER TABLE task ADD FULLTEXT KEY ft_task_description (description);
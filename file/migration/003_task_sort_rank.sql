@@ -0,0 +1,9 @@
+-- Adds the manual ordering of tasks. Existing tasks keep their creation order by getting
+-- their zero-padded id as rank, tasks created afterwards are ranked after them.
+ALTER TABLE task ADD COLUMN sort_rank VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL DEFAULT '' AFTER complete;
+
+UPDATE task SET sort_rank = LPAD(id, 20, '0') WHERE sort_rank = '';
+
+-- Without a default an insert must always carry a rank. The ranks are made unique per tenant
+-- in 006_task_tenant.sql.
+ALTER TABLE task ALTER COLUMN sort_rank DROP DEFAULT;
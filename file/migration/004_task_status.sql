@@ -0,0 +1,5 @@
+-- Replaces the completed flag with a workflow status. Completed tasks move to the done
+-- status, use workflow.done instead of 'done' if it is configured differently.
+ALTER TABLE task ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'todo' AFTER description;
+
+UPDATE task SET status = 'done' WHERE complete = 1;
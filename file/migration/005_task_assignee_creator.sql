@@ -0,0 +1,5 @@
+-- Adds the assignee and the creator of tasks. Existing tasks are unassigned and have no
+-- known creator.
+ALTER TABLE task
+    ADD COLUMN assignee_id VARCHAR(64) NOT NULL DEFAULT '' AFTER sort_rank,
+    ADD COLUMN creator_id VARCHAR(64) NOT NULL DEFAULT '' AFTER assignee_id;
@@ -0,0 +1,10 @@
+-- Scopes tasks by tenant. Existing tasks belong to the empty tenant, the one of callers whose
+-- token carries no tenant; assign them to a tenant with an UPDATE before serving tenants.
+ALTER TABLE task ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT '' AFTER id;
+
+-- The ranks backfilled by 003_task_sort_rank.sql are unique across the table, so the unique
+-- key rejects two tasks of a tenant getting the same rank from concurrent creates or moves.
+ALTER TABLE task
+    ADD UNIQUE KEY uk_task_tenant_sort_rank (tenant_id, sort_rank),
+    ADD KEY idx_task_tenant_assignee_id (tenant_id, assignee_id),
+    ADD KEY idx_task_tenant_creator_id (tenant_id, creator_id);
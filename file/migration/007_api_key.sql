@@ -0,0 +1,18 @@
+-- Adds the api keys.
+CREATE TABLE IF NOT EXISTS api_key (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    tenant_id    VARCHAR(64)  NOT NULL DEFAULT '',
+    name         VARCHAR(255) NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    key_hash     CHAR(64)     NOT NULL,
+    scopes       VARCHAR(255) NOT NULL DEFAULT '',
+    creator_id   VARCHAR(64)  NOT NULL DEFAULT '',
+    expires_at   DATETIME     NULL,
+    revoked_at   DATETIME     NULL,
+    last_used_at DATETIME     NULL,
+    last_used_ip VARCHAR(64)  NOT NULL DEFAULT '',
+    created_at   DATETIME     NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_api_key_key_hash (key_hash),
+    KEY idx_api_key_tenant_id (tenant_id)
+);
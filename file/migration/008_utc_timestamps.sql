@@ -0,0 +1,15 @@
+-- Converts the times written with database.timezone Asia/Jakarta, the location the server used
+-- before it became configurable, to UTC. Apply it while the server is stopped and start it
+-- again with database.timezone UTC. Asia/Jakarta has no daylight saving, so a fixed offset is
+-- exact and does not need the time zone tables.
+UPDATE task SET
+    created_at = CONVERT_TZ(created_at, '+07:00', '+00:00'),
+    updated_at = CONVERT_TZ(updated_at, '+07:00', '+00:00');
+
+UPDATE task_attachment SET created_at = CONVERT_TZ(created_at, '+07:00', '+00:00');
+
+UPDATE api_key SET
+    expires_at = CONVERT_TZ(expires_at, '+07:00', '+00:00'),
+    revoked_at = CONVERT_TZ(revoked_at, '+07:00', '+00:00'),
+    last_used_at = CONVERT_TZ(last_used_at, '+07:00', '+00:00'),
+    created_at = CONVERT_TZ(created_at, '+07:00', '+00:00');
@@ -0,0 +1,51 @@
+-- Schema of a new database. A database created with the original task table is upgraded to
+-- this schema by applying every script in file/migration in order.
+CREATE TABLE IF NOT EXISTS task (
+    id          BIGINT       NOT NULL AUTO_INCREMENT,
+    tenant_id   VARCHAR(64)  NOT NULL DEFAULT '',
+    description TEXT         NOT NULL,
+    status      VARCHAR(32)  NOT NULL DEFAULT 'todo',
+    complete    BOOLEAN      NOT NULL DEFAULT FALSE,
+    sort_rank   VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL,
+    assignee_id VARCHAR(64)  NOT NULL DEFAULT '',
+    creator_id  VARCHAR(64)  NOT NULL DEFAULT '',
+    created_at  DATETIME     NOT NULL,
+    updated_at  DATETIME     NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_task_tenant_sort_rank (tenant_id, sort_rank),
+    KEY idx_task_tenant_assignee_id (tenant_id, assignee_id),
+    KEY idx_task_tenant_creator_id (tenant_id, creator_id),
+    FULLTEXT KEY ft_task_description (description)
+);
+
+CREATE TABLE IF NOT EXISTS task_attachment (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    task_id      BIGINT       NOT NULL,
+    name         VARCHAR(255) NOT NULL,
+    size         BIGINT       NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    sha256       CHAR(64)     NOT NULL,
+    storage_key  VARCHAR(255) NOT NULL,
+    created_at   DATETIME     NOT NULL,
+    PRIMARY KEY (id),
+    KEY idx_task_attachment_task_id (task_id),
+    CONSTRAINT fk_task_attachment_task FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS api_key (
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    tenant_id    VARCHAR(64)  NOT NULL DEFAULT '',
+    name         VARCHAR(255) NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    key_hash     CHAR(64)     NOT NULL,
+    scopes       VARCHAR(255) NOT NULL DEFAULT '',
+    creator_id   VARCHAR(64)  NOT NULL DEFAULT '',
+    expires_at   DATETIME     NULL,
+    revoked_at   DATETIME     NULL,
+    last_used_at DATETIME     NULL,
+    last_used_ip VARCHAR(64)  NOT NULL DEFAULT '',
+    created_at   DATETIME     NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_api_key_key_hash (key_hash),
+    KEY idx_api_key_tenant_id (tenant_id)
+);
@@ -0,0 +1,410 @@
+// Package gateway serves the todo service as an HTTP/JSON API. Every request is translated
+// into the matching gRPC request and handed to the same TodoServer through the same unary
+// interceptors as gRPC calls, so authentication, rate limiting, logging and metrics apply
+// alike. The streaming attachment RPCs are only available over gRPC.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/winartodev/protobuff-collections/todolist"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxBodySize bounds the JSON request bodies, which only carry small messages.
+const maxBodySize = 1 << 20
+
+// forwardedHeaders are passed on to the interceptors as incoming gRPC metadata.
+var forwardedHeaders = []string{"authorization", "x-api-key", "x-request-id", "traceparent", "tracestate", "baggage"}
+
+// Gateway routes:
+//
+//	GET    /v1/tasks               GetListTask, filters in the query string
+//	POST   /v1/tasks               CreateTask, the task as body
+//	GET    /v1/tasks:search        SearchTasks, query and limit in the query string
+//	GET    /v1/tasks/{id}          GetTaskByID
+//	PATCH  /v1/tasks/{id}          UpdateTask
+//	DELETE /v1/tasks/{id}          DeleteTask
+//	POST   /v1/tasks/{id}:move     MoveTask
+//	POST   /v1/tasks/{id}:assign   AssignTask
+//	POST   /v1/api-keys            CreateApiKey
+//	DELETE /v1/api-keys/{id}       RevokeApiKey
+type Gateway struct {
+	Todo           todolist.TodoServer
+	Interceptors   []grpc.UnaryServerInterceptor
+	AllowedOrigins []string
+}
+
+func NewGateway(todo todolist.TodoServer, interceptors []grpc.UnaryServerInterceptor, allowedOrigins []string) *Gateway {
+	return &Gateway{
+		Todo:           todo,
+		Interceptors:   interceptors,
+		AllowedOrigins: allowedOrigins,
+	}
+}
+
+// route is one RPC reachable over HTTP. newRequest returns the empty request message, body
+// names the field filled from the JSON body, "*" for the whole request and "" for none.
+type route struct {
+	method     string
+	rpc        string
+	body       string
+	newRequest func() proto.Message
+	call       func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error)
+}
+
+var (
+	tasksRoutes = []route{
+		{http.MethodGet, "GetListTask", "", func() proto.Message { return &todolist.GetListOfTaskRequest{} },
+			func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+				return todo.GetListTask(ctx, req.(*todolist.GetListOfTaskRequest))
+			}},
+		{http.MethodPost, "CreateTask", "task", func() proto.Message { return &todolist.CreateTaskRequest{} },
+			func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+				return todo.CreateTask(ctx, req.(*todolist.CreateTaskRequest))
+			}},
+	}
+
+	searchRoutes = []route{
+		{http.MethodGet, "SearchTasks", "", func() proto.Message { return &todolist.SearchTasksRequest{} },
+			func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+				return todo.SearchTasks(ctx, req.(*todolist.SearchTasksRequest))
+			}},
+	}
+
+	taskRoutes = []route{
+		{http.MethodGet, "GetTaskByID", "", func() proto.Message { return &todolist.GetTaskByIDRequest{} },
+			func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+				return todo.GetTaskByID(ctx, req.(*todolist.GetTaskByIDRequest))
+			}},
+		{http.MethodPatch, "UpdateTask", "*", func() proto.Message { return &todolist.UpdateTaskRequest{} },
+			func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+				return todo.UpdateTask(ctx, req.(*todolist.UpdateTaskRequest))
+			}},
+		{http.MethodDelete, "DeleteTask", "", func() proto.Message { return &todolist.DeleteTaskRequest{} },
+			func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+				return todo.DeleteTask(ctx, req.(*todolist.DeleteTaskRequest))
+			}},
+	}
+
+	taskActionRoutes = map[string][]route{
+		"move": {
+			{http.MethodPost, "MoveTask", "*", func() proto.Message { return &todolist.MoveTaskRequest{} },
+				func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+					return todo.MoveTask(ctx, req.(*todolist.MoveTaskRequest))
+				}},
+		},
+		"assign": {
+			{http.MethodPost, "AssignTask", "*", func() proto.Message { return &todolist.AssignTaskRequest{} },
+				func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+					return todo.AssignTask(ctx, req.(*todolist.AssignTaskRequest))
+				}},
+		},
+	}
+
+	apiKeysRoutes = []route{
+		{http.MethodPost, "CreateApiKey", "*", func() proto.Message { return &todolist.CreateApiKeyRequest{} },
+			func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+				return todo.CreateApiKey(ctx, req.(*todolist.CreateApiKeyRequest))
+			}},
+	}
+
+	apiKeyRoutes = []route{
+		{http.MethodDelete, "RevokeApiKey", "", func() proto.Message { return &todolist.RevokeApiKeyRequest{} },
+			func(ctx context.Context, todo todolist.TodoServer, req proto.Message) (proto.Message, error) {
+				return todo.RevokeApiKey(ctx, req.(*todolist.RevokeApiKeyRequest))
+			}},
+	}
+)
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if g.cors(w, r) {
+		return
+	}
+
+	routes, id, ok := match(r.URL.Path)
+	if !ok {
+		writeError(w, status.Error(codes.NotFound, "no route for "+r.URL.Path))
+		return
+	}
+
+	for _, rt := range routes {
+		if rt.method == r.Method {
+			g.serve(w, r, rt, id)
+			return
+		}
+	}
+
+	var allowed []string
+	for _, rt := range routes {
+		allowed = append(allowed, rt.method)
+	}
+
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeJSONError(w, http.StatusMethodNotAllowed, codes.Unimplemented, "method "+r.Method+" not allowed")
+}
+
+// match finds the routes of path and the task or key id it contains, if any.
+func match(path string) (routes []route, id string, ok bool) {
+	switch {
+	case path == "/v1/tasks":
+		return tasksRoutes, "", true
+	case path == "/v1/tasks:search":
+		return searchRoutes, "", true
+	case path == "/v1/api-keys":
+		return apiKeysRoutes, "", true
+	case strings.HasPrefix(path, "/v1/api-keys/"):
+		id = strings.TrimPrefix(path, "/v1/api-keys/")
+		return apiKeyRoutes, id, id != "" && !strings.Contains(id, "/")
+	case strings.HasPrefix(path, "/v1/tasks/"):
+		id = strings.TrimPrefix(path, "/v1/tasks/")
+		if id == "" || strings.Contains(id, "/") {
+			return nil, "", false
+		}
+
+		if id, action, found := strings.Cut(id, ":"); found {
+			routes, ok := taskActionRoutes[action]
+			return routes, id, ok && id != ""
+		}
+
+		return taskRoutes, id, true
+	}
+
+	return nil, "", false
+}
+
+func (g *Gateway) serve(w http.ResponseWriter, r *http.Request, rt route, id string) {
+	req := rt.newRequest()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	if err := decodeRequest(r, rt.body, req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, codes.ResourceExhausted, fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit))
+			return
+		}
+
+		writeError(w, err)
+		return
+	}
+
+	if id != "" {
+		if err := setFields(req, url.Values{"id": {id}}); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	fullMethod := "/" + todolist.Todo_ServiceDesc.ServiceName + "/" + rt.rpc
+	stream := &transportStream{method: fullMethod}
+	ctx := grpc.NewContextWithServerTransportStream(incomingContext(r), stream)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return rt.call(ctx, g.Todo, req.(proto.Message))
+	}
+
+	resp, err := chain(g.Interceptors, &grpc.UnaryServerInfo{Server: g.Todo, FullMethod: fullMethod}, handler)(ctx, req)
+
+	for key, values := range stream.header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	out, err := protojson.Marshal(resp.(proto.Message))
+	if err != nil {
+		writeError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(out)
+}
+
+// decodeRequest fills req from the JSON body, for routes with one, and then the query string.
+// The body comes first since unmarshalling resets the message. A body over the limit of
+// http.MaxBytesReader is returned as is, for the caller to answer with 413.
+func decodeRequest(r *http.Request, body string, req proto.Message) error {
+	if body != "" {
+		content, err := io.ReadAll(r.Body)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return err
+		}
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		if len(content) > 0 {
+			target := req
+			if body != "*" {
+				message := req.ProtoReflect()
+				field := message.Descriptor().Fields().ByName(protoreflect.Name(body))
+				target = message.Mutable(field).Message().Interface()
+			}
+
+			if err := protojson.Unmarshal(content, target); err != nil {
+				return status.Error(codes.InvalidArgument, "invalid JSON body: "+err.Error())
+			}
+		}
+	}
+
+	return setFields(req, r.URL.Query())
+}
+
+// setFields sets the scalar fields of req named, by JSON or proto name, in values.
+func setFields(req proto.Message, values url.Values) error {
+	message := req.ProtoReflect()
+	fields := message.Descriptor().Fields()
+
+	for name, raw := range values {
+		field := fields.ByJSONName(name)
+		if field == nil {
+			field = fields.ByName(protoreflect.Name(name))
+		}
+		if field == nil || len(raw) == 0 {
+			return status.Errorf(codes.InvalidArgument, "unknown parameter %q", name)
+		}
+
+		value, err := parseScalar(field, raw[0])
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "parameter %q: %v", name, err)
+		}
+
+		message.Set(field, value)
+	}
+
+	return nil
+}
+
+func parseScalar(field protoreflect.FieldDescriptor, raw string) (protoreflect.Value, error) {
+	if field.IsList() || field.IsMap() {
+		return protoreflect.Value{}, status.Error(codes.InvalidArgument, "not a scalar field")
+	}
+
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(raw), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(raw)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind:
+		n, err := strconv.ParseInt(raw, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.DoubleKind:
+		n, err := strconv.ParseFloat(raw, 64)
+		return protoreflect.ValueOfFloat64(n), err
+	default:
+		return protoreflect.Value{}, status.Errorf(codes.InvalidArgument, "unsupported type %s", field.Kind())
+	}
+}
+
+// incomingContext carries the forwarded headers as metadata and the client address as peer,
+// like a gRPC call would.
+func incomingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, key := range forwardedHeaders {
+		if values := r.Header.Values(key); len(values) > 0 {
+			md.Set(key, values...)
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+
+	return ctx
+}
+
+// cors answers preflight requests and sets the CORS headers for allowed origins. It reports
+// whether the request was fully handled.
+func (g *Gateway) cors(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || !g.allowedOrigin(origin) {
+		return false
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Add("Vary", "Origin")
+
+	if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+		return false
+	}
+
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE")
+	w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Api-Key, X-Request-Id")
+	w.WriteHeader(http.StatusNoContent)
+
+	return true
+}
+
+func (g *Gateway) allowedOrigin(origin string) bool {
+	for _, allowed := range g.AllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// chain runs the interceptors in order around handler, like grpc.ChainUnaryInterceptor.
+func chain(interceptors []grpc.UnaryServerInterceptor, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) grpc.UnaryHandler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+
+	return handler
+}
+
+// transportStream collects the headers set by the interceptors and handlers, such as the
+// x-request-id, so they can be sent as HTTP headers.
+type transportStream struct {
+	method string
+	header metadata.MD
+}
+
+func (ts *transportStream) Method() string {
+	return ts.method
+}
+
+func (ts *transportStream) SetHeader(md metadata.MD) error {
+	ts.header = metadata.Join(ts.header, md)
+	return nil
+}
+
+func (ts *transportStream) SendHeader(md metadata.MD) error {
+	return ts.SetHeader(md)
+}
+
+func (ts *transportStream) SetTrailer(md metadata.MD) error {
+	return nil
+}
@@ -0,0 +1,292 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/winartodev/protobuff-collections/todolist"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeTodoServer records the last request and answers every call with a task of the same id.
+type fakeTodoServer struct {
+	todolist.UnimplementedTodoServer
+	request proto.Message
+	err     error
+}
+
+func (f *fakeTodoServer) task(req proto.Message, id int64) (*todolist.Task, error) {
+	f.request = req
+	return &todolist.Task{Id: id, Description: "write tests"}, f.err
+}
+
+func (f *fakeTodoServer) CreateTask(ctx context.Context, req *todolist.CreateTaskRequest) (*todolist.CreateTaskResponse, error) {
+	task, err := f.task(req, 1)
+	return &todolist.CreateTaskResponse{Task: task}, err
+}
+
+func (f *fakeTodoServer) GetListTask(ctx context.Context, req *todolist.GetListOfTaskRequest) (*todolist.ListOfTasksResponse, error) {
+	task, err := f.task(req, 1)
+	return &todolist.ListOfTasksResponse{Task: []*todolist.Task{task}}, err
+}
+
+func (f *fakeTodoServer) GetTaskByID(ctx context.Context, req *todolist.GetTaskByIDRequest) (*todolist.GetTaskByIDResponse, error) {
+	task, err := f.task(req, req.Id)
+	return &todolist.GetTaskByIDResponse{Task: task}, err
+}
+
+func (f *fakeTodoServer) UpdateTask(ctx context.Context, req *todolist.UpdateTaskRequest) (*todolist.UpdateTaskResponse, error) {
+	task, err := f.task(req, req.Id)
+	return &todolist.UpdateTaskResponse{Task: task}, err
+}
+
+func (f *fakeTodoServer) MoveTask(ctx context.Context, req *todolist.MoveTaskRequest) (*todolist.MoveTaskResponse, error) {
+	task, err := f.task(req, req.Id)
+	return &todolist.MoveTaskResponse{Task: task}, err
+}
+
+func (f *fakeTodoServer) SearchTasks(ctx context.Context, req *todolist.SearchTasksRequest) (*todolist.SearchTasksResponse, error) {
+	_, err := f.task(req, 0)
+	return &todolist.SearchTasksResponse{}, err
+}
+
+func TestGateway_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		err         error
+		wantStatus  int
+		wantRequest proto.Message
+		wantBody    string
+	}{
+		{
+			name:        "List Tasks",
+			method:      http.MethodGet,
+			target:      "/v1/tasks?assigneeId=bob&assignedToMe=true",
+			wantStatus:  http.StatusOK,
+			wantRequest: &todolist.GetListOfTaskRequest{AssigneeId: "bob", AssignedToMe: true},
+			wantBody:    `"task":[{"id":"1"`,
+		},
+		{
+			name:        "Create Task",
+			method:      http.MethodPost,
+			target:      "/v1/tasks",
+			body:        `{"description": "write tests", "status": "todo"}`,
+			wantStatus:  http.StatusOK,
+			wantRequest: &todolist.CreateTaskRequest{Task: &todolist.Task{Description: "write tests", Status: "todo"}},
+			wantBody:    `"description":"write tests"`,
+		},
+		{
+			name:        "Get Task",
+			method:      http.MethodGet,
+			target:      "/v1/tasks/42",
+			wantStatus:  http.StatusOK,
+			wantRequest: &todolist.GetTaskByIDRequest{Id: 42},
+			wantBody:    `"id":"42"`,
+		},
+		{
+			name:        "Update Task Path ID Wins",
+			method:      http.MethodPatch,
+			target:      "/v1/tasks/42",
+			body:        `{"id": "7", "completed": true}`,
+			wantStatus:  http.StatusOK,
+			wantRequest: &todolist.UpdateTaskRequest{Id: 42, Completed: true},
+		},
+		{
+			name:        "Move Task",
+			method:      http.MethodPost,
+			target:      "/v1/tasks/42:move",
+			body:        `{"beforeId": "3"}`,
+			wantStatus:  http.StatusOK,
+			wantRequest: &todolist.MoveTaskRequest{Id: 42, BeforeId: 3},
+		},
+		{
+			name:        "Search Tasks",
+			method:      http.MethodGet,
+			target:      "/v1/tasks:search?query=milk&limit=5",
+			wantStatus:  http.StatusOK,
+			wantRequest: &todolist.SearchTasksRequest{Query: "milk", Limit: 5},
+		},
+		{
+			name:       "Not Found",
+			method:     http.MethodGet,
+			target:     "/v1/tasks/42",
+			err:        status.Error(codes.NotFound, "task 42 not found"),
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"code":"NotFound","message":"task 42 not found"}`,
+		},
+		{
+			name:       "Invalid ID",
+			method:     http.MethodGet,
+			target:     "/v1/tasks/abc",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"code":"InvalidArgument"`,
+		},
+		{
+			name:       "Unknown Parameter",
+			method:     http.MethodGet,
+			target:     "/v1/tasks?color=red",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `unknown parameter \"color\"`,
+		},
+		{
+			name:       "Invalid Body",
+			method:     http.MethodPost,
+			target:     "/v1/tasks",
+			body:       `{"description":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Body Too Large",
+			method:     http.MethodPost,
+			target:     "/v1/tasks",
+			body:       `{"description": "` + strings.Repeat("a", maxBodySize) + `"}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `"code":"ResourceExhausted"`,
+		},
+		{
+			name:       "Unknown Route",
+			method:     http.MethodGet,
+			target:     "/v1/projects",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Unknown Action",
+			method:     http.MethodPost,
+			target:     "/v1/tasks/42:archive",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Method Not Allowed",
+			method:     http.MethodPut,
+			target:     "/v1/tasks/42",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "Unimplemented",
+			method:     http.MethodDelete,
+			target:     "/v1/tasks/42",
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &fakeTodoServer{err: tt.err}
+			g := NewGateway(todo, nil, nil)
+
+			rec := httptest.NewRecorder()
+			g.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("ServeHTTP() status = %v, want %v, body %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+
+			if tt.wantRequest != nil && !proto.Equal(todo.request, tt.wantRequest) {
+				t.Errorf("ServeHTTP() request = %v, want %v", todo.request, tt.wantRequest)
+			}
+
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("ServeHTTP() body = %s, want to contain %s", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestGateway_Interceptors(t *testing.T) {
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name+" "+info.FullMethod)
+			return handler(ctx, req)
+		}
+	}
+
+	auth := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer token" {
+			return nil, status.Error(codes.Unauthenticated, "missing token")
+		}
+
+		grpc.SetHeader(ctx, metadata.Pairs("x-request-id", "req-1"))
+
+		return handler(ctx, req)
+	}
+
+	g := NewGateway(&fakeTodoServer{}, []grpc.UnaryServerInterceptor{record("first"), record("second"), auth}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/tasks/1", nil)
+	req.Header.Set("Authorization", "Bearer token")
+
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() status = %v, want %v, body %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	want := []string{"first /todolist.Todo/GetTaskByID", "second /todolist.Todo/GetTaskByID"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("interceptor calls = %v, want %v", calls, want)
+	}
+
+	if got := rec.Header().Get("X-Request-Id"); got != "req-1" {
+		t.Errorf("X-Request-Id = %q, want %q", got, "req-1")
+	}
+
+	rec = httptest.NewRecorder()
+	g.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/tasks/1", nil))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("ServeHTTP() without token status = %v, want %v", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestGateway_CORS(t *testing.T) {
+	tests := []struct {
+		name       string
+		origin     string
+		wantStatus int
+		wantOrigin string
+	}{
+		{
+			name:       "Allowed Origin",
+			origin:     "http://localhost:3000",
+			wantStatus: http.StatusNoContent,
+			wantOrigin: "http://localhost:3000",
+		},
+		{
+			name:       "Other Origin",
+			origin:     "http://evil.example",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGateway(&fakeTodoServer{}, nil, []string{"http://localhost:3000"})
+
+			req := httptest.NewRequest(http.MethodOptions, "/v1/tasks", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+			rec := httptest.NewRecorder()
+			g.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("ServeHTTP() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+		})
+	}
+}
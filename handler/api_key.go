@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/winartodev/go-grpc/types"
+	"github.com/winartodev/go-grpc/util"
+	"github.com/winartodev/protobuff-collections/todolist"
+)
+
+func (th *TodoHandler) CreateApiKey(ctx context.Context, req *todolist.CreateApiKeyRequest) (*todolist.CreateApiKeyResponse, error) {
+	data := types.APIKey{
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	}
+
+	if req.ExpiresAt != 0 {
+		expiresAt := time.Unix(req.ExpiresAt, 0)
+		data.ExpiresAt = &expiresAt
+	}
+
+	apiKey, key, err := th.APIKeyUsecase.Create(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &todolist.CreateApiKeyResponse{
+		ApiKey: util.TransformAPIKeyDataRPC(apiKey),
+		Key:    key,
+	}, nil
+}
+
+func (th *TodoHandler) RevokeApiKey(ctx context.Context, req *todolist.RevokeApiKeyRequest) (*todolist.RevokeApiKeyResponse, error) {
+	err := th.APIKeyUsecase.Revoke(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &todolist.RevokeApiKeyResponse{}, nil
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/winartodev/go-grpc/types"
+	todoUsecaseMock "github.com/winartodev/go-grpc/usecase/mocks"
+	"github.com/winartodev/go-grpc/util"
+	"github.com/winartodev/protobuff-collections/todolist"
+)
+
+func TestTodoHandler_CreateApiKey(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Unix(1700000000, 0)
+	apiKey := &types.APIKey{ID: 1, Name: "nightly export", Prefix: "tk_0123abcd", Scopes: []string{"read"}, ExpiresAt: &expiresAt}
+
+	apiKeyUsecase := new(todoUsecaseMock.APIKeyUsecaseInterface)
+	apiKeyUsecase.On("Create", ctx, types.APIKey{Name: "nightly export", Scopes: []string{"read"}, ExpiresAt: &expiresAt}).Return(apiKey, "tk_0123abcdef", nil)
+
+	th := &TodoHandler{
+		APIKeyUsecase: apiKeyUsecase,
+	}
+	got, err := th.CreateApiKey(ctx, &todolist.CreateApiKeyRequest{Name: "nightly export", Scopes: []string{"read"}, ExpiresAt: 1700000000})
+	if err != nil {
+		t.Fatalf("TodoHandler.CreateApiKey() error = %v", err)
+	}
+
+	want := &todolist.CreateApiKeyResponse{
+		ApiKey: util.TransformAPIKeyDataRPC(apiKey),
+		Key:    "tk_0123abcdef",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TodoHandler.CreateApiKey() = %v, want %v", got, want)
+	}
+}
+
+func TestTodoHandler_RevokeApiKey(t *testing.T) {
+	ctx := context.Background()
+
+	apiKeyUsecase := new(todoUsecaseMock.APIKeyUsecaseInterface)
+	apiKeyUsecase.On("Revoke", ctx, int64(1)).Return(nil).Once()
+
+	th := &TodoHandler{
+		APIKeyUsecase: apiKeyUsecase,
+	}
+	if _, err := th.RevokeApiKey(ctx, &todolist.RevokeApiKeyRequest{Id: 1}); err != nil {
+		t.Errorf("TodoHandler.RevokeApiKey() error = %v", err)
+	}
+	apiKeyUsecase.AssertExpectations(t)
+}
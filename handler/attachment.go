@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/winartodev/go-grpc/usecase"
+	"github.com/winartodev/go-grpc/util"
+	"github.com/winartodev/protobuff-collections/todolist"
+)
+
+const attachmentChunkSize = 32 << 10
+
+func (th *TodoHandler) UploadAttachment(stream todolist.Todo_UploadAttachmentServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	info := req.GetInfo()
+	if info == nil {
+		return fmt.Errorf("%w: first upload message must contain the attachment info", usecase.ErrInvalidArgument)
+	}
+
+	data := util.TransformAttachmentInfoData(info)
+
+	attachment, err := th.AttachmentUsecase.Upload(stream.Context(), data, &attachmentUploadReader{stream: stream})
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&todolist.UploadAttachmentResponse{
+		Attachment: util.TransformAttachmentDataRPC(attachment),
+	})
+}
+
+func (th *TodoHandler) DownloadAttachment(req *todolist.DownloadAttachmentRequest, stream todolist.Todo_DownloadAttachmentServer) error {
+	attachment, content, err := th.AttachmentUsecase.Download(stream.Context(), req.Id)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	err = stream.Send(&todolist.DownloadAttachmentResponse{
+		Data: &todolist.DownloadAttachmentResponse_Attachment{
+			Attachment: util.TransformAttachmentDataRPC(attachment),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			sendErr := stream.Send(&todolist.DownloadAttachmentResponse{
+				Data: &todolist.DownloadAttachmentResponse_Chunk{
+					Chunk: buf[:n],
+				},
+			})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// attachmentUploadReader exposes the chunks of an upload stream as an io.Reader.
+type attachmentUploadReader struct {
+	stream todolist.Todo_UploadAttachmentServer
+	buf    []byte
+}
+
+func (r *attachmentUploadReader) Read(p []byte) (n int, err error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		r.buf = req.GetChunk()
+	}
+
+	n = copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/winartodev/go-grpc/types"
+	todoUsecaseMock "github.com/winartodev/go-grpc/usecase/mocks"
+	"github.com/winartodev/protobuff-collections/todolist"
+	"google.golang.org/grpc"
+)
+
+type uploadAttachmentStreamMock struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*todolist.UploadAttachmentRequest
+	response *todolist.UploadAttachmentResponse
+}
+
+func (s *uploadAttachmentStreamMock) Context() context.Context {
+	return s.ctx
+}
+
+func (s *uploadAttachmentStreamMock) Recv() (*todolist.UploadAttachmentRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	return req, nil
+}
+
+func (s *uploadAttachmentStreamMock) SendAndClose(res *todolist.UploadAttachmentResponse) error {
+	s.response = res
+	return nil
+}
+
+type downloadAttachmentStreamMock struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*todolist.DownloadAttachmentResponse
+}
+
+func (s *downloadAttachmentStreamMock) Context() context.Context {
+	return s.ctx
+}
+
+func (s *downloadAttachmentStreamMock) Send(res *todolist.DownloadAttachmentResponse) error {
+	s.responses = append(s.responses, res)
+	return nil
+}
+
+var attachmentDataMock = types.Attachment{
+	ID:          1,
+	TaskID:      1,
+	Name:        "notes.txt",
+	Size:        5,
+	ContentType: "text/plain",
+	SHA256:      "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+}
+
+func TestTodoHandler_UploadAttachment(t *testing.T) {
+	ctx := context.Background()
+	attachmentUsecase := new(todoUsecaseMock.AttachmentUsecaseInterface)
+
+	var uploaded []byte
+	attachmentUsecase.On("Upload", ctx, types.Attachment{TaskID: 1, Name: "notes.txt", ContentType: "text/plain"}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			uploaded, _ = io.ReadAll(args.Get(2).(io.Reader))
+		}).
+		Return(&attachmentDataMock, nil)
+
+	stream := &uploadAttachmentStreamMock{
+		ctx: ctx,
+		requests: []*todolist.UploadAttachmentRequest{
+			{Data: &todolist.UploadAttachmentRequest_Info{Info: &todolist.AttachmentInfo{TaskId: 1, Name: "notes.txt", ContentType: "text/plain"}}},
+			{Data: &todolist.UploadAttachmentRequest_Chunk{Chunk: []byte("hel")}},
+			{Data: &todolist.UploadAttachmentRequest_Chunk{Chunk: []byte("lo")}},
+		},
+	}
+
+	th := &TodoHandler{
+		AttachmentUsecase: attachmentUsecase,
+	}
+	if err := th.UploadAttachment(stream); err != nil {
+		t.Fatalf("TodoHandler.UploadAttachment() error = %v", err)
+	}
+
+	if string(uploaded) != "hello" {
+		t.Errorf("TodoHandler.UploadAttachment() uploaded = %s, want %s", uploaded, "hello")
+	}
+
+	want := &todolist.UploadAttachmentResponse{
+		Attachment: &todolist.Attachment{
+			Id:          1,
+			TaskId:      1,
+			Name:        "notes.txt",
+			Size:        5,
+			ContentType: "text/plain",
+			Sha256:      attachmentDataMock.SHA256,
+		},
+	}
+	if !reflect.DeepEqual(stream.response, want) {
+		t.Errorf("TodoHandler.UploadAttachment() = %v, want %v", stream.response, want)
+	}
+}
+
+func TestTodoHandler_UploadAttachment_MissingInfo(t *testing.T) {
+	stream := &uploadAttachmentStreamMock{
+		ctx: context.Background(),
+		requests: []*todolist.UploadAttachmentRequest{
+			{Data: &todolist.UploadAttachmentRequest_Chunk{Chunk: []byte("hello")}},
+		},
+	}
+
+	th := &TodoHandler{
+		AttachmentUsecase: new(todoUsecaseMock.AttachmentUsecaseInterface),
+	}
+	if err := th.UploadAttachment(stream); err == nil {
+		t.Errorf("TodoHandler.UploadAttachment() error = nil, want error")
+	}
+}
+
+func TestTodoHandler_DownloadAttachment(t *testing.T) {
+	ctx := context.Background()
+	attachmentUsecase := new(todoUsecaseMock.AttachmentUsecaseInterface)
+
+	content := bytes.Repeat([]byte("a"), attachmentChunkSize+1)
+	attachmentUsecase.On("Download", ctx, int64(1)).Return(&attachmentDataMock, io.NopCloser(bytes.NewReader(content)), nil)
+
+	stream := &downloadAttachmentStreamMock{
+		ctx: ctx,
+	}
+
+	th := &TodoHandler{
+		AttachmentUsecase: attachmentUsecase,
+	}
+	if err := th.DownloadAttachment(&todolist.DownloadAttachmentRequest{Id: 1}, stream); err != nil {
+		t.Fatalf("TodoHandler.DownloadAttachment() error = %v", err)
+	}
+
+	if len(stream.responses) != 3 {
+		t.Fatalf("TodoHandler.DownloadAttachment() sent %d messages, want %d", len(stream.responses), 3)
+	}
+
+	if stream.responses[0].GetAttachment().GetId() != attachmentDataMock.ID {
+		t.Errorf("TodoHandler.DownloadAttachment() first message = %v, want attachment metadata", stream.responses[0])
+	}
+
+	var got []byte
+	for _, res := range stream.responses[1:] {
+		got = append(got, res.GetChunk()...)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("TodoHandler.DownloadAttachment() content length = %d, want %d", len(got), len(content))
+	}
+}
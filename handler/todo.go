@@ -13,17 +13,25 @@ import (
 
 type TodoHandler struct {
 	todolist.UnimplementedTodoServer
-	TodoUsecase usecase.TodoUsecaseInterface
+	TodoUsecase       usecase.TodoUsecaseInterface
+	AttachmentUsecase usecase.AttachmentUsecaseInterface
+	APIKeyUsecase     usecase.APIKeyUsecaseInterface
 }
 
-func NewTodoHandler(grpcServer *grpc.Server, todoUsecase usecase.TodoUsecaseInterface) {
+// NewTodoHandler registers the todo service on grpcServer and returns it, so that it can also
+// be served by the gateway.
+func NewTodoHandler(grpcServer *grpc.Server, todoUsecase usecase.TodoUsecaseInterface, attachmentUsecase usecase.AttachmentUsecaseInterface, apiKeyUsecase usecase.APIKeyUsecaseInterface) *TodoHandler {
 	todoHandler := &TodoHandler{
-		TodoUsecase: todoUsecase,
+		TodoUsecase:       todoUsecase,
+		AttachmentUsecase: attachmentUsecase,
+		APIKeyUsecase:     apiKeyUsecase,
 	}
 
 	todolist.RegisterTodoServer(grpcServer, todoHandler)
 
 	reflection.Register(grpcServer)
+
+	return todoHandler
 }
 
 func (th *TodoHandler) CreateTask(ctx context.Context, req *todolist.CreateTaskRequest) (*todolist.CreateTaskResponse, error) {
@@ -51,7 +59,11 @@ func (th *TodoHandler) DeleteTask(ctx context.Context, req *todolist.DeleteTaskR
 }
 
 func (th *TodoHandler) GetListTask(ctx context.Context, req *todolist.GetListOfTaskRequest) (*todolist.ListOfTasksResponse, error) {
-	tasks, err := th.TodoUsecase.GetAll(ctx)
+	tasks, err := th.TodoUsecase.GetAll(ctx, types.TaskFilter{
+		AssigneeID:   req.AssigneeId,
+		AssignedToMe: req.AssignedToMe,
+		CreatedByMe:  req.CreatedByMe,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +92,51 @@ func (th *TodoHandler) GetTaskByID(ctx context.Context, req *todolist.GetTaskByI
 	}, nil
 }
 
+func (th *TodoHandler) AssignTask(ctx context.Context, req *todolist.AssignTaskRequest) (*todolist.AssignTaskResponse, error) {
+	task, err := th.TodoUsecase.Assign(ctx, req.Id, req.AssigneeId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := util.TransformTaskDataRPC(task)
+
+	return &todolist.AssignTaskResponse{
+		Task: res,
+	}, nil
+}
+
+func (th *TodoHandler) MoveTask(ctx context.Context, req *todolist.MoveTaskRequest) (*todolist.MoveTaskResponse, error) {
+	task, err := th.TodoUsecase.Move(ctx, req.Id, req.BeforeId, req.AfterId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := util.TransformTaskDataRPC(task)
+
+	return &todolist.MoveTaskResponse{
+		Task: res,
+	}, nil
+}
+
+func (th *TodoHandler) SearchTasks(ctx context.Context, req *todolist.SearchTasksRequest) (*todolist.SearchTasksResponse, error) {
+	results, err := th.TodoUsecase.Search(ctx, req.Query, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	var searchResults []*todolist.TaskSearchResult
+	for _, result := range results {
+		searchResults = append(searchResults, util.TransformTaskSearchResultRPC(&result))
+	}
+
+	return &todolist.SearchTasksResponse{
+		Result: searchResults,
+	}, nil
+}
+
 func (th *TodoHandler) UpdateTask(ctx context.Context, req *todolist.UpdateTaskRequest) (*todolist.UpdateTaskResponse, error) {
 	task, err := th.TodoUsecase.Update(ctx, req.Id, types.Task{
+		Status:      req.Status,
 		Completed:   req.Completed,
 		Description: req.Description,
 	})
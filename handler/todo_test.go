@@ -28,8 +28,10 @@ func newTodoHandler() todoHandlerMock {
 
 func TestNewTodoHandler(t *testing.T) {
 	type args struct {
-		grpcServer  *grpc.Server
-		todoUsecase usecase.TodoUsecaseInterface
+		grpcServer        *grpc.Server
+		todoUsecase       usecase.TodoUsecaseInterface
+		attachmentUsecase usecase.AttachmentUsecaseInterface
+		apiKeyUsecase     usecase.APIKeyUsecaseInterface
 	}
 	tests := []struct {
 		name string
@@ -38,14 +40,16 @@ func TestNewTodoHandler(t *testing.T) {
 		{
 			name: "Success",
 			args: args{
-				grpcServer:  grpc.NewServer(),
-				todoUsecase: &usecase.TodoUsecase{},
+				grpcServer:        grpc.NewServer(),
+				todoUsecase:       &usecase.TodoUsecase{},
+				attachmentUsecase: &usecase.AttachmentUsecase{},
+				apiKeyUsecase:     &usecase.APIKeyUsecase{},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			NewTodoHandler(tt.args.grpcServer, tt.args.todoUsecase)
+			NewTodoHandler(tt.args.grpcServer, tt.args.todoUsecase, tt.args.attachmentUsecase, tt.args.apiKeyUsecase)
 		})
 	}
 }
@@ -250,7 +254,7 @@ func TestTodoHandler_GetListTask(t *testing.T) {
 				},
 			},
 			mock: func() {
-				todoHandlerMock.TodoUsecase.On("GetAll", ctx).Return(data, nil).Times(1)
+				todoHandlerMock.TodoUsecase.On("GetAll", ctx, types.TaskFilter{}).Return(data, nil).Times(1)
 			},
 		},
 	}
@@ -349,6 +353,247 @@ func TestTodoHandler_GetTaskByID(t *testing.T) {
 	}
 }
 
+func TestTodoHandler_AssignTask(t *testing.T) {
+	todoHandlerMock := newTodoHandler()
+	ctx := context.Background()
+
+	mockTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	data := types.Task{
+		ID:          1,
+		Description: "Description",
+		AssigneeID:  "bob",
+		CreatorID:   "alice",
+		CreatedAt:   &mockTime,
+		UpdatedAt:   &mockTime,
+	}
+
+	type fields struct {
+		UnimplementedTodoServer todolist.UnimplementedTodoServer
+		TodoUsecase             usecase.TodoUsecaseInterface
+	}
+	type args struct {
+		ctx context.Context
+		req *todolist.AssignTaskRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *todolist.AssignTaskResponse
+		wantErr bool
+		mock    func()
+	}{
+		{
+			name: "Success Assign Task GRPC",
+			fields: fields{
+				UnimplementedTodoServer: todolist.UnimplementedTodoServer{},
+				TodoUsecase:             todoHandlerMock.TodoUsecase,
+			},
+			args: args{
+				ctx: ctx,
+				req: &todolist.AssignTaskRequest{
+					Id:         1,
+					AssigneeId: "bob",
+				},
+			},
+			want: &todolist.AssignTaskResponse{
+				Task: &todolist.Task{
+					Id:          1,
+					Description: "Description",
+					AssigneeId:  "bob",
+					CreatorId:   "alice",
+					CreatedAt:   mockTime.Unix(),
+					UpdatedAt:   mockTime.Unix(),
+				},
+			},
+			mock: func() {
+				todoHandlerMock.TodoUsecase.On("Assign", ctx, int64(1), "bob").Return(&data, nil).Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			th := &TodoHandler{
+				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
+				TodoUsecase:             tt.fields.TodoUsecase,
+			}
+			got, err := th.AssignTask(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoHandler.AssignTask() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TodoHandler.AssignTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTodoHandler_MoveTask(t *testing.T) {
+	todoHandlerMock := newTodoHandler()
+	ctx := context.Background()
+
+	mockTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	data := types.Task{
+		ID:          1,
+		Description: "Description",
+		Rank:        "aV",
+		CreatedAt:   &mockTime,
+		UpdatedAt:   &mockTime,
+	}
+
+	type fields struct {
+		UnimplementedTodoServer todolist.UnimplementedTodoServer
+		TodoUsecase             usecase.TodoUsecaseInterface
+	}
+	type args struct {
+		ctx context.Context
+		req *todolist.MoveTaskRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *todolist.MoveTaskResponse
+		wantErr bool
+		mock    func()
+	}{
+		{
+			name: "Success Move Task GRPC",
+			fields: fields{
+				UnimplementedTodoServer: todolist.UnimplementedTodoServer{},
+				TodoUsecase:             todoHandlerMock.TodoUsecase,
+			},
+			args: args{
+				ctx: ctx,
+				req: &todolist.MoveTaskRequest{
+					Id:       1,
+					BeforeId: 2,
+					AfterId:  3,
+				},
+			},
+			want: &todolist.MoveTaskResponse{
+				Task: &todolist.Task{
+					Id:          1,
+					Description: "Description",
+					Rank:        "aV",
+					CreatedAt:   mockTime.Unix(),
+					UpdatedAt:   mockTime.Unix(),
+				},
+			},
+			mock: func() {
+				todoHandlerMock.TodoUsecase.On("Move", ctx, int64(1), int64(2), int64(3)).Return(&data, nil).Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			th := &TodoHandler{
+				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
+				TodoUsecase:             tt.fields.TodoUsecase,
+			}
+			got, err := th.MoveTask(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoHandler.MoveTask() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TodoHandler.MoveTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTodoHandler_SearchTasks(t *testing.T) {
+	todoHandlerMock := newTodoHandler()
+	ctx := context.Background()
+
+	mockTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	data := []types.TaskSearchResult{
+		{
+			Task: types.Task{
+				ID:          1,
+				Description: "Description",
+				Completed:   false,
+				CreatedAt:   &mockTime,
+				UpdatedAt:   &mockTime,
+			},
+			Score: 1.5,
+		},
+	}
+
+	type fields struct {
+		UnimplementedTodoServer todolist.UnimplementedTodoServer
+		TodoUsecase             usecase.TodoUsecaseInterface
+	}
+	type args struct {
+		ctx context.Context
+		req *todolist.SearchTasksRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *todolist.SearchTasksResponse
+		wantErr bool
+		mock    func()
+	}{
+		{
+			name: "Success Search Tasks GRPC",
+			fields: fields{
+				UnimplementedTodoServer: todolist.UnimplementedTodoServer{},
+				TodoUsecase:             todoHandlerMock.TodoUsecase,
+			},
+			args: args{
+				ctx: ctx,
+				req: &todolist.SearchTasksRequest{
+					Query: "description",
+					Limit: 10,
+				},
+			},
+			want: &todolist.SearchTasksResponse{
+				Result: []*todolist.TaskSearchResult{
+					{
+						Task: &todolist.Task{
+							Id:          1,
+							Description: "Description",
+							Completed:   false,
+							CreatedAt:   mockTime.Unix(),
+							UpdatedAt:   mockTime.Unix(),
+						},
+						Score: 1.5,
+					},
+				},
+			},
+			mock: func() {
+				todoHandlerMock.TodoUsecase.On("Search", ctx, "description", 10).Return(data, nil).Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			th := &TodoHandler{
+				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
+				TodoUsecase:             tt.fields.TodoUsecase,
+			}
+			got, err := th.SearchTasks(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoHandler.SearchTasks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TodoHandler.SearchTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTodoHandler_UpdateTask(t *testing.T) {
 	todoHandlerMock := newTodoHandler()
 	ctx := context.Background()
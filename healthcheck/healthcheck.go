@@ -0,0 +1,107 @@
+// Package healthcheck keeps the gRPC health status of the server in line with the reachability
+// of its database.
+package healthcheck
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	DefaultInterval = 10 * time.Second
+	DefaultTimeout  = 2 * time.Second
+)
+
+// Pinger is satisfied by *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// StatusSetter is satisfied by *health.Server.
+type StatusSetter interface {
+	SetServingStatus(service string, servingStatus healthpb.HealthCheckResponse_ServingStatus)
+}
+
+// Probe pings the database and reports SERVING for Services while it answers and NOT_SERVING
+// otherwise. The empty service name stands for the server as a whole.
+type Probe struct {
+	DB       Pinger
+	Health   StatusSetter
+	Services []string
+	Interval time.Duration
+	Timeout  time.Duration
+
+	mu      sync.Mutex
+	serving bool
+	checked bool
+}
+
+func NewProbe(db Pinger, health StatusSetter, interval time.Duration, timeout time.Duration, services ...string) *Probe {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &Probe{
+		DB:       db,
+		Health:   health,
+		Services: services,
+		Interval: interval,
+		Timeout:  timeout,
+	}
+}
+
+// Check pings the database once, updates the health status and reports whether it is serving.
+func (p *Probe) Check(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	err := p.DB.PingContext(ctx)
+	serving := err == nil
+
+	p.mu.Lock()
+	changed := !p.checked || p.serving != serving
+	p.serving, p.checked = serving, true
+	p.mu.Unlock()
+
+	if !changed {
+		return serving
+	}
+
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+		slog.Info("database is reachable, serving")
+	} else {
+		slog.Warn("database is unreachable, not serving", "error", err)
+	}
+
+	for _, service := range p.Services {
+		p.Health.SetServingStatus(service, status)
+	}
+
+	return serving
+}
+
+// Run checks the database right away and then every Interval until ctx is done.
+func (p *Probe) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
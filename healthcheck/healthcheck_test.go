@@ -0,0 +1,72 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (f *fakePinger) PingContext(ctx context.Context) error {
+	return f.err
+}
+
+func TestProbe_Check(t *testing.T) {
+	ctx := context.Background()
+	db := &fakePinger{}
+	healthServer := health.NewServer()
+	probe := NewProbe(db, healthServer, 0, 0, "", "todolist.Todo")
+
+	status := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		res, err := healthServer.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) error = %v", service, err)
+		}
+		return res.Status
+	}
+
+	steps := []struct {
+		name    string
+		pingErr error
+		want    healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name: "Reachable",
+			want: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:    "Unreachable",
+			pingErr: errors.New("connection refused"),
+			want:    healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name: "Recovered",
+			want: healthpb.HealthCheckResponse_SERVING,
+		},
+	}
+	for _, tt := range steps {
+		db.err = tt.pingErr
+
+		if got := probe.Check(ctx); got != (tt.pingErr == nil) {
+			t.Errorf("%s: Check() = %v, want %v", tt.name, got, tt.pingErr == nil)
+		}
+		for _, service := range []string{"", "todolist.Todo"} {
+			if got := status(service); got != tt.want {
+				t.Errorf("%s: status(%q) = %v, want %v", tt.name, service, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestNewProbe_Defaults(t *testing.T) {
+	probe := NewProbe(&fakePinger{}, health.NewServer(), 0, 0)
+	if probe.Interval != DefaultInterval || probe.Timeout != DefaultTimeout {
+		t.Errorf("NewProbe() = %v, %v, want %v, %v", probe.Interval, probe.Timeout, DefaultInterval, DefaultTimeout)
+	}
+}
@@ -0,0 +1,151 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/winartodev/go-grpc/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultAuthAllowlist holds the method prefixes served without credentials.
+var DefaultAuthAllowlist = []string{
+	"/grpc.reflection.v1alpha.ServerReflection/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.health.v1.Health/",
+}
+
+type TokenAuthenticator interface {
+	Authenticate(token string) (principal auth.Principal, err error)
+}
+
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string, ip string) (principal auth.Principal, err error)
+}
+
+// AuthInterceptor accepts either an x-api-key or a bearer token in the authorization
+// metadata. API keys are rejected when no KeyAuthenticator is set.
+type AuthInterceptor struct {
+	Authenticator    TokenAuthenticator
+	KeyAuthenticator KeyAuthenticator
+	Allowlist        []string
+}
+
+func NewAuthInterceptor(authenticator TokenAuthenticator, keyAuthenticator KeyAuthenticator, allowlist []string) *AuthInterceptor {
+	if len(allowlist) == 0 {
+		allowlist = DefaultAuthAllowlist
+	}
+
+	return &AuthInterceptor{
+		Authenticator:    authenticator,
+		KeyAuthenticator: keyAuthenticator,
+		Allowlist:        allowlist,
+	}
+}
+
+func (ai *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := ai.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (ai *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := ai.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (ai *AuthInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if ai.allowed(method) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		if ai.KeyAuthenticator == nil {
+			return nil, status.Error(codes.Unauthenticated, "api keys are not accepted")
+		}
+
+		principal, err := ai.KeyAuthenticator.Authenticate(ctx, keys[0], peerIP(ctx))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return auth.NewContext(ctx, principal), nil
+	}
+
+	token, err := bearerToken(md)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := ai.Authenticator.Authenticate(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+func (ai *AuthInterceptor) allowed(method string) bool {
+	for _, prefix := range ai.Allowlist {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func bearerToken(md metadata.MD) (string, error) {
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") || strings.TrimSpace(token) == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization metadata is not a bearer token")
+	}
+
+	return strings.TrimSpace(token), nil
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,178 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/winartodev/go-grpc/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAuthenticator struct{}
+
+func (fakeAuthenticator) Authenticate(token string) (auth.Principal, error) {
+	if token != "good" {
+		return auth.Principal{}, errors.New("invalid token")
+	}
+
+	return auth.Principal{UserID: "alice"}, nil
+}
+
+type fakeKeyAuthenticator struct{}
+
+func (fakeKeyAuthenticator) Authenticate(ctx context.Context, key string, ip string) (auth.Principal, error) {
+	if key != "tk_good" || ip != "10.0.0.7" {
+		return auth.Principal{}, errors.New("invalid api key")
+	}
+
+	return auth.Principal{UserID: "apikey:1"}, nil
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context {
+	return f.ctx
+}
+
+func incomingContext(authorization string) context.Context {
+	if authorization == "" {
+		return context.Background()
+	}
+
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+}
+
+func apiKeyContext(key string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 51000}})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", key))
+}
+
+func TestAuthInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		ctx           context.Context
+		authorization string
+		wantUser      string
+		wantCode      codes.Code
+	}{
+		{
+			name:          "Valid Token",
+			method:        "/todolist.Todo/GetListTask",
+			authorization: "Bearer good",
+			wantUser:      "alice",
+			wantCode:      codes.OK,
+		},
+		{
+			name:     "Missing Metadata",
+			method:   "/todolist.Todo/GetListTask",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:          "Not Bearer",
+			method:        "/todolist.Todo/GetListTask",
+			authorization: "Basic good",
+			wantCode:      codes.Unauthenticated,
+		},
+		{
+			name:          "Invalid Token",
+			method:        "/todolist.Todo/GetListTask",
+			authorization: "Bearer bad",
+			wantCode:      codes.Unauthenticated,
+		},
+		{
+			name:     "Valid API Key",
+			method:   "/todolist.Todo/GetListTask",
+			ctx:      apiKeyContext("tk_good"),
+			wantUser: "apikey:1",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "Invalid API Key",
+			method:   "/todolist.Todo/GetListTask",
+			ctx:      apiKeyContext("tk_bad"),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "Allowlisted",
+			method:   "/grpc.health.v1.Health/Check",
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewAuthInterceptor(fakeAuthenticator{}, fakeKeyAuthenticator{}, nil)
+
+			var gotUser string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				principal, _ := auth.FromContext(ctx)
+				gotUser = principal.UserID
+				return nil, nil
+			}
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = incomingContext(tt.authorization)
+			}
+
+			_, err := interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Unary() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if gotUser != tt.wantUser {
+				t.Errorf("Unary() principal = %v, want %v", gotUser, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor_Stream(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		wantUser      string
+		wantCode      codes.Code
+	}{
+		{
+			name:          "Valid Token",
+			authorization: "bearer good",
+			wantUser:      "alice",
+			wantCode:      codes.OK,
+		},
+		{
+			name:          "Invalid Token",
+			authorization: "Bearer bad",
+			wantCode:      codes.Unauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewAuthInterceptor(fakeAuthenticator{}, fakeKeyAuthenticator{}, nil)
+
+			var gotUser string
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				principal, _ := auth.FromContext(stream.Context())
+				gotUser = principal.UserID
+				return nil
+			}
+
+			stream := &fakeServerStream{ctx: incomingContext(tt.authorization)}
+			err := interceptor.Stream()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/todolist.Todo/UploadAttachment"}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Stream() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if gotUser != tt.wantUser {
+				t.Errorf("Stream() principal = %v, want %v", gotUser, tt.wantUser)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	mathrand "math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDKey = "x-request-id"
+
+type requestIDContextKey struct{}
+
+// RequestIDFromContext returns the id the logging interceptor assigned to the request.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// LoggingInterceptor writes one record per call. Failed calls are always logged, successful
+// ones only for a SampleRate fraction of calls; a rate outside (0, 1] logs every call.
+type LoggingInterceptor struct {
+	Logger     *slog.Logger
+	SampleRate float64
+}
+
+func NewLoggingInterceptor(logger *slog.Logger, sampleRate float64) *LoggingInterceptor {
+	return &LoggingInterceptor{
+		Logger:     logger,
+		SampleRate: sampleRate,
+	}
+}
+
+func (li *LoggingInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = li.withRequestID(ctx)
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		li.log(ctx, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+func (li *LoggingInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := li.withRequestID(ss.Context())
+		start := time.Now()
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+
+		li.log(ctx, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// withRequestID reuses the x-request-id sent by the client or generates one, and echoes it
+// back in the response headers.
+func (li *LoggingInterceptor) withRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var id string
+	if values := md.Get(requestIDKey); len(values) > 0 && values[0] != "" {
+		id = values[0]
+	} else {
+		id = newRequestID()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+func (li *LoggingInterceptor) log(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := levelForCode(code)
+
+	if err == nil && li.SampleRate > 0 && li.SampleRate < 1 && mathrand.Float64() >= li.SampleRate {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+		slog.String("peer", peerIP(ctx)),
+		slog.String("request_id", RequestIDFromContext(ctx)),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+
+	li.Logger.LogAttrs(ctx, level, "grpc call", attrs...)
+}
+
+// levelForCode logs caller mistakes as warnings and server faults as errors.
+func levelForCode(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented, codes.DeadlineExceeded:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(buf)
+}
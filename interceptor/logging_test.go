@@ -0,0 +1,87 @@
+package interceptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoggingInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name       string
+		sampleRate float64
+		err        error
+		wantLogged bool
+		wantLevel  string
+		wantCode   string
+	}{
+		{
+			name:       "Success",
+			wantLogged: true,
+			wantLevel:  "INFO",
+			wantCode:   "OK",
+		},
+		{
+			name:       "Success Sampled Out",
+			sampleRate: 0.0000001,
+			wantLogged: false,
+		},
+		{
+			name:       "Client Error Always Logged",
+			sampleRate: 0.0000001,
+			err:        status.Error(codes.NotFound, "task not found"),
+			wantLogged: true,
+			wantLevel:  "WARN",
+			wantCode:   "NotFound",
+		},
+		{
+			name:       "Server Error",
+			err:        status.Error(codes.Internal, "boom"),
+			wantLogged: true,
+			wantLevel:  "ERROR",
+			wantCode:   "Internal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+			interceptor := NewLoggingInterceptor(logger, tt.sampleRate)
+
+			var gotRequestID string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				gotRequestID = RequestIDFromContext(ctx)
+				return nil, tt.err
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-1"))
+			interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/todolist.Todo/GetTaskByID"}, handler)
+
+			if gotRequestID != "req-1" {
+				t.Errorf("request id = %v, want req-1", gotRequestID)
+			}
+
+			if !tt.wantLogged {
+				if buf.Len() != 0 {
+					t.Errorf("output = %q, want nothing", buf.String())
+				}
+				return
+			}
+
+			var record map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("output %q is not one json record: %v", buf.String(), err)
+			}
+			if record["level"] != tt.wantLevel || record["code"] != tt.wantCode || record["method"] != "/todolist.Todo/GetTaskByID" || record["request_id"] != "req-1" {
+				t.Errorf("record = %v, want level %v code %v", record, tt.wantLevel, tt.wantCode)
+			}
+		})
+	}
+}
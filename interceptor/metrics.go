@@ -0,0 +1,48 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type RPCObserver interface {
+	ObserveRPC(method string, code string, duration time.Duration)
+}
+
+// MetricsInterceptor records the count, status code and latency of every call.
+type MetricsInterceptor struct {
+	Observer RPCObserver
+}
+
+func NewMetricsInterceptor(observer RPCObserver) *MetricsInterceptor {
+	return &MetricsInterceptor{
+		Observer: observer,
+	}
+}
+
+func (mi *MetricsInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		mi.Observer.ObserveRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+
+		return resp, err
+	}
+}
+
+func (mi *MetricsInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		mi.Observer.ObserveRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+
+		return err
+	}
+}
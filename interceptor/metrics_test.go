@@ -0,0 +1,36 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeRPCObserver struct {
+	method string
+	code   string
+}
+
+func (f *fakeRPCObserver) ObserveRPC(method string, code string, duration time.Duration) {
+	f.method = method
+	f.code = code
+}
+
+func TestMetricsInterceptor_Unary(t *testing.T) {
+	observer := &fakeRPCObserver{}
+	interceptor := NewMetricsInterceptor(observer)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+
+	interceptor.Unary()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/todolist.Todo/GetTaskByID"}, handler)
+
+	if observer.method != "/todolist.Todo/GetTaskByID" || observer.code != "NotFound" {
+		t.Errorf("observed %v %v, want /todolist.Todo/GetTaskByID NotFound", observer.method, observer.code)
+	}
+}
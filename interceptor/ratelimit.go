@@ -0,0 +1,132 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimitInterceptor throttles every caller with its own token bucket per method, and caps
+// its calls to all methods together with an optional quota. Callers are told apart by
+// principal, or by peer address when the request is not authenticated, so it must run after
+// the auth interceptor. Without a default limiter, methods without their own limiter are not
+// throttled.
+type RateLimitInterceptor struct {
+	Default *ratelimit.Limiter
+	Methods map[string]*ratelimit.Limiter
+	Quota   *ratelimit.Quota
+
+	mu sync.RWMutex
+}
+
+func NewRateLimitInterceptor(defaultLimiter *ratelimit.Limiter, methods map[string]*ratelimit.Limiter) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		Default: defaultLimiter,
+		Methods: methods,
+	}
+}
+
+// SetLimiters replaces the limiters, e.g. after a config reload. Passing nil and no methods
+// turns rate limiting off.
+func (rli *RateLimitInterceptor) SetLimiters(defaultLimiter *ratelimit.Limiter, methods map[string]*ratelimit.Limiter) {
+	rli.mu.Lock()
+	defer rli.mu.Unlock()
+
+	rli.Default = defaultLimiter
+	rli.Methods = methods
+}
+
+// SetQuota replaces the quota, nil turns quotas off.
+func (rli *RateLimitInterceptor) SetQuota(quota *ratelimit.Quota) {
+	rli.mu.Lock()
+	defer rli.mu.Unlock()
+
+	rli.Quota = quota
+}
+
+func (rli *RateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := rli.limit(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (rli *RateLimitInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := rli.limit(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func (rli *RateLimitInterceptor) limit(ctx context.Context, method string) error {
+	rli.mu.RLock()
+	limiter, ok := rli.Methods[method]
+	if !ok {
+		limiter = rli.Default
+	}
+	quota := rli.Quota
+	rli.mu.RUnlock()
+
+	caller := callerKey(ctx)
+
+	// The rate limit is checked first so that throttled calls do not use up the quota.
+	if limiter != nil {
+		if allowed, retryAfter := limiter.Allow(method + " " + caller); !allowed {
+			return exhausted("rate limit exceeded for "+method, retryAfter)
+		}
+	}
+
+	if quota != nil {
+		if allowed, retryAfter := quota.Allow(caller); !allowed {
+			return exhausted("quota exceeded", retryAfter, &errdetails.QuotaFailure{
+				Violations: []*errdetails.QuotaFailure_Violation{{
+					Subject:     caller,
+					Description: fmt.Sprintf("%d calls per %s", quota.Limit, quota.Period),
+				}},
+			})
+		}
+	}
+
+	return nil
+}
+
+// exhausted builds a ResourceExhausted error telling the caller when to retry.
+func exhausted(message string, retryAfter time.Duration, details ...protoiface.MessageV1) error {
+	st := status.New(codes.ResourceExhausted, message)
+	details = append([]protoiface.MessageV1{&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	}}, details...)
+
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func callerKey(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return "principal:" + principal.TenantID + "/" + principal.UserID
+	}
+
+	return "peer:" + peerIP(ctx)
+}
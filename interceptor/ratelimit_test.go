@@ -0,0 +1,140 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitInterceptor_Unary(t *testing.T) {
+	interceptor := NewRateLimitInterceptor(ratelimit.NewLimiter(100, 100), map[string]*ratelimit.Limiter{
+		"/todolist.Todo/GetListTask": ratelimit.NewLimiter(1, 1),
+	})
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	call := func(ctx context.Context, method string) error {
+		_, err := interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	alice := auth.NewContext(context.Background(), auth.Principal{UserID: "alice"})
+	bob := auth.NewContext(context.Background(), auth.Principal{UserID: "bob"})
+
+	if err := call(alice, "/todolist.Todo/GetListTask"); err != nil {
+		t.Fatalf("first call error = %v", err)
+	}
+
+	err := call(alice, "/todolist.Todo/GetListTask")
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second call code = %v, want %v", status.Code(err), codes.ResourceExhausted)
+	}
+
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	if retryInfo == nil || retryInfo.RetryDelay.AsDuration() <= 0 {
+		t.Errorf("error details = %v, want a positive retry delay", status.Convert(err).Details())
+	}
+
+	if err := call(bob, "/todolist.Todo/GetListTask"); err != nil {
+		t.Errorf("other caller error = %v, want its own bucket", err)
+	}
+
+	if err := call(alice, "/todolist.Todo/GetTaskByID"); err != nil {
+		t.Errorf("other method error = %v, want the default limit", err)
+	}
+}
+
+func TestRateLimitInterceptor_SetLimiters(t *testing.T) {
+	rli := NewRateLimitInterceptor(nil, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/todolist.Todo/GetListTask"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	call := func() codes.Code {
+		_, err := rli.Unary()(context.Background(), nil, info, handler)
+		return status.Code(err)
+	}
+
+	if got := call(); got != codes.OK {
+		t.Fatalf("without limiters code = %v, want %v", got, codes.OK)
+	}
+
+	rli.SetLimiters(nil, map[string]*ratelimit.Limiter{info.FullMethod: ratelimit.NewLimiter(0, 1)})
+	if first, second := call(), call(); first != codes.OK || second != codes.ResourceExhausted {
+		t.Errorf("after SetLimiters codes = %v, %v, want %v, %v", first, second, codes.OK, codes.ResourceExhausted)
+	}
+
+	rli.SetLimiters(nil, nil)
+	if got := call(); got != codes.OK {
+		t.Errorf("after turning off code = %v, want %v", got, codes.OK)
+	}
+}
+
+func TestRateLimitInterceptor_Quota(t *testing.T) {
+	rli := NewRateLimitInterceptor(nil, nil)
+	rli.SetQuota(ratelimit.NewQuota(2, time.Hour))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	call := func(ctx context.Context, method string) error {
+		_, err := rli.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	alice := auth.NewContext(context.Background(), auth.Principal{UserID: "alice"})
+	bob := auth.NewContext(context.Background(), auth.Principal{UserID: "bob"})
+
+	// The quota is shared by all methods.
+	if err := call(alice, "/todolist.Todo/GetListTask"); err != nil {
+		t.Fatalf("first call error = %v", err)
+	}
+	if err := call(alice, "/todolist.Todo/GetTaskByID"); err != nil {
+		t.Fatalf("second call error = %v", err)
+	}
+
+	err := call(alice, "/todolist.Todo/CreateTask")
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("third call code = %v, want %v", status.Code(err), codes.ResourceExhausted)
+	}
+
+	var quotaFailure *errdetails.QuotaFailure
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		switch detail := detail.(type) {
+		case *errdetails.QuotaFailure:
+			quotaFailure = detail
+		case *errdetails.RetryInfo:
+			retryInfo = detail
+		}
+	}
+	if quotaFailure == nil || len(quotaFailure.Violations) != 1 || quotaFailure.Violations[0].Subject != "principal:/alice" {
+		t.Errorf("quota failure = %v, want a violation for alice", quotaFailure)
+	}
+	if retryInfo == nil || retryInfo.RetryDelay.AsDuration() <= 0 {
+		t.Errorf("retry info = %v, want a positive retry delay", retryInfo)
+	}
+
+	if err := call(bob, "/todolist.Todo/GetListTask"); err != nil {
+		t.Errorf("other caller error = %v, want its own quota", err)
+	}
+
+	rli.SetQuota(nil)
+	if err := call(alice, "/todolist.Todo/GetListTask"); err != nil {
+		t.Errorf("after turning off error = %v, want no quota", err)
+	}
+}
@@ -0,0 +1,68 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type PanicObserver interface {
+	ObservePanic(method string)
+}
+
+// RecoveryInterceptor turns a panic in a handler into an Internal error instead of letting it
+// crash the process. The stack is logged with the request id; Observer may be nil.
+type RecoveryInterceptor struct {
+	Logger   *slog.Logger
+	Observer PanicObserver
+}
+
+func NewRecoveryInterceptor(logger *slog.Logger, observer PanicObserver) *RecoveryInterceptor {
+	return &RecoveryInterceptor{
+		Logger:   logger,
+		Observer: observer,
+	}
+}
+
+func (ri *RecoveryInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = ri.recovered(ctx, info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func (ri *RecoveryInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = ri.recovered(ss.Context(), info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func (ri *RecoveryInterceptor) recovered(ctx context.Context, method string, r interface{}) error {
+	ri.Logger.ErrorContext(ctx, "panic in handler",
+		"method", method,
+		"request_id", RequestIDFromContext(ctx),
+		"panic", r,
+		"stack", string(debug.Stack()),
+	)
+
+	if ri.Observer != nil {
+		ri.Observer.ObservePanic(method)
+	}
+
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,110 @@
+package interceptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakePanicObserver struct {
+	methods []string
+}
+
+func (f *fakePanicObserver) ObservePanic(method string) {
+	f.methods = append(f.methods, method)
+}
+
+func TestRecoveryInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   grpc.UnaryHandler
+		wantCode  codes.Code
+		wantPanic bool
+	}{
+		{
+			name: "No Panic",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "ok", nil
+			},
+			wantCode:  codes.OK,
+			wantPanic: false,
+		},
+		{
+			name: "Handler Error",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, status.Error(codes.NotFound, "task not found")
+			},
+			wantCode:  codes.NotFound,
+			wantPanic: false,
+		},
+		{
+			name: "Nil Dereference",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				var task *struct{ Description string }
+				return task.Description, nil
+			},
+			wantCode:  codes.Internal,
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			observer := &fakePanicObserver{}
+			interceptor := NewRecoveryInterceptor(slog.New(slog.NewJSONHandler(&buf, nil)), observer)
+
+			ctx := context.WithValue(context.Background(), requestIDContextKey{}, "req-1")
+			info := &grpc.UnaryServerInfo{FullMethod: "/todolist.Todo/CreateTask"}
+
+			_, err := interceptor.Unary()(ctx, nil, info, tt.handler)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("Unary() code = %v, want %v", got, tt.wantCode)
+			}
+
+			if !tt.wantPanic {
+				if buf.Len() != 0 || len(observer.methods) != 0 {
+					t.Errorf("Unary() reported a panic that did not happen: %s", buf.String())
+				}
+				return
+			}
+
+			var record map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("log record %q: %v", buf.String(), err)
+			}
+			if record["request_id"] != "req-1" || record["method"] != info.FullMethod {
+				t.Errorf("log record = %v, want request_id req-1 and method %s", record, info.FullMethod)
+			}
+			if stack, _ := record["stack"].(string); !strings.Contains(stack, "recovery_test.go") {
+				t.Errorf("log record stack does not point at the handler: %q", stack)
+			}
+			if len(observer.methods) != 1 || observer.methods[0] != info.FullMethod {
+				t.Errorf("observed panics = %v, want [%s]", observer.methods, info.FullMethod)
+			}
+		})
+	}
+}
+
+func TestRecoveryInterceptor_Stream(t *testing.T) {
+	var buf bytes.Buffer
+	interceptor := NewRecoveryInterceptor(slog.New(slog.NewJSONHandler(&buf, nil)), nil)
+
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		panic("boom")
+	}
+
+	err := interceptor.Stream()(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/todolist.Todo/UploadAttachment"}, handler)
+	if got := status.Code(err); got != codes.Internal {
+		t.Fatalf("Stream() code = %v, want %v", got, codes.Internal)
+	}
+	if !strings.Contains(buf.String(), "boom") {
+		t.Errorf("log does not mention the panic value: %s", buf.String())
+	}
+}
@@ -0,0 +1,46 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/winartodev/go-grpc/authz"
+	"github.com/winartodev/go-grpc/usecase"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StatusUnaryInterceptor converts the usecase errors that have a matching gRPC code into
+// status errors, so clients can tell them apart from internal failures.
+func StatusUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	return resp, toStatus(err)
+}
+
+func StatusStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return toStatus(handler(srv, ss))
+}
+
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, authz.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, authz.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, usecase.ErrInvalidArgument), errors.Is(err, usecase.ErrAttachmentTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,74 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/winartodev/go-grpc/authz"
+	"github.com/winartodev/go-grpc/usecase"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{
+			name:     "Success",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "Permission Denied",
+			err:      fmt.Errorf("%w: role %q cannot delete task 1", authz.ErrPermissionDenied, "viewer"),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "Unauthenticated",
+			err:      authz.ErrUnauthenticated,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "Not Found",
+			err:      fmt.Errorf("task with id 1 was %w", usecase.ErrNotFound),
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Invalid Argument",
+			err:      fmt.Errorf("%w: unknown task status %q", usecase.ErrInvalidArgument, "archived"),
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Attachment Too Large",
+			err:      usecase.ErrAttachmentTooLarge,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Status Kept",
+			err:      status.Error(codes.NotFound, "not found"),
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Other Error",
+			err:      errors.New("boom"),
+			wantCode: codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.err
+			}
+
+			_, err := StatusUnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("StatusUnaryInterceptor() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTracingUnaryInterceptor(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	info := &grpc.UnaryServerInfo{FullMethod: "/todolist.Todo/GetTaskByID"}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		handlerErr error
+		wantParent bool
+		wantStatus otelcodes.Code
+	}{
+		{
+			name:       "PropagatedFromMetadata",
+			ctx:        metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", traceparent)),
+			wantParent: true,
+			wantStatus: otelcodes.Unset,
+		},
+		{
+			name:       "NewTrace",
+			ctx:        context.Background(),
+			wantParent: false,
+			wantStatus: otelcodes.Unset,
+		},
+		{
+			name:       "Error",
+			ctx:        context.Background(),
+			handlerErr: status.Error(codes.NotFound, "task not found"),
+			wantParent: false,
+			wantStatus: otelcodes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+			var handlerSpan trace.SpanContext
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return nil, tt.handlerErr
+			}
+
+			_, err := TracingUnaryInterceptor(tt.ctx, nil, info, handler)
+			if err != tt.handlerErr {
+				t.Fatalf("TracingUnaryInterceptor() error = %v, want %v", err, tt.handlerErr)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("ended spans = %d, want 1", len(spans))
+			}
+
+			span := spans[0]
+			if span.Name() != info.FullMethod || span.SpanKind() != trace.SpanKindServer {
+				t.Errorf("span = %s (%v), want %s (server)", span.Name(), span.SpanKind(), info.FullMethod)
+			}
+			if span.SpanContext().SpanID() != handlerSpan.SpanID() {
+				t.Errorf("handler context does not carry the server span")
+			}
+			if got := span.Parent().IsValid(); got != tt.wantParent {
+				t.Errorf("span has parent = %v, want %v", got, tt.wantParent)
+			}
+			if tt.wantParent && span.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+				t.Errorf("trace id = %s, want the propagated one", span.SpanContext().TraceID())
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    string
+		format   string
+		wantErr  bool
+		wantLine string
+	}{
+		{
+			name:     "JSON",
+			level:    "warn",
+			format:   "json",
+			wantLine: `"msg":"shown"`,
+		},
+		{
+			name:     "Text Default Level",
+			format:   "text",
+			wantLine: "msg=shown",
+		},
+		{
+			name:    "Unknown Level",
+			level:   "loud",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Format",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := New(&buf, tt.level, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			logger.Debug("hidden")
+			logger.Warn("shown")
+
+			if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), tt.wantLine) {
+				t.Errorf("output = %q, want only %q", buf.String(), tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	got, err := ParseLevel("ERROR")
+	if err != nil || got != slog.LevelError {
+		t.Errorf("ParseLevel() = %v, %v, want %v", got, err, slog.LevelError)
+	}
+}
+
+func TestNewWithLevel(t *testing.T) {
+	var buf bytes.Buffer
+	var level slog.LevelVar
+	level.Set(slog.LevelWarn)
+
+	logger, err := NewWithLevel(&buf, &level, "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("hidden")
+	level.Set(slog.LevelInfo)
+	logger.Info("shown")
+
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), "msg=shown") {
+		t.Errorf("output = %q, want only the record logged after lowering the level", buf.String())
+	}
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "todolist"
+
+// Metrics owns the registry served on /metrics and the collectors recorded by the
+// interceptors and repositories.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	rpcRequests *prometheus.CounterVec
+	rpcLatency  *prometheus.HistogramVec
+	rpcPanics   *prometheus.CounterVec
+	dbLatency   *prometheus.HistogramVec
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		rpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "gRPC calls handled, by method and status code.",
+		}, []string{"method", "code"}),
+		rpcLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "gRPC call latency, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		rpcPanics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "panics_total",
+			Help:      "Panics recovered in gRPC handlers, by method.",
+		}, []string{"method"}),
+		dbLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Repository method latency, by repository, method and whether it failed.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"repository", "method", "error"}),
+	}
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.rpcRequests,
+		m.rpcLatency,
+		m.rpcPanics,
+		m.dbLatency,
+	)
+
+	return m
+}
+
+// RegisterDB exports the connection pool statistics of db.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.Registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+func (m *Metrics) ObserveRPC(method string, code string, duration time.Duration) {
+	m.rpcRequests.WithLabelValues(method, code).Inc()
+	m.rpcLatency.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObservePanic(method string) {
+	m.rpcPanics.WithLabelValues(method).Inc()
+}
+
+func (m *Metrics) ObserveQuery(repository string, method string, duration time.Duration, err error) {
+	failed := "false"
+	if err != nil {
+		failed = "true"
+	}
+
+	m.dbLatency.WithLabelValues(repository, method, failed).Observe(duration.Seconds())
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{})
+}
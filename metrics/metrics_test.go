@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestMetrics_Handler(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	m := NewMetrics()
+	m.RegisterDB(db, "todo-db")
+	m.ObserveRPC("/todolist.Todo/GetListTask", "OK", 20*time.Millisecond)
+	m.ObserveQuery("todo", "GetAllTaskDB", 5*time.Millisecond, errors.New("timeout"))
+	m.ObservePanic("/todolist.Todo/CreateTask")
+
+	server := httptest.NewServer(m.Handler())
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	for _, want := range []string{
+		`todolist_grpc_requests_total{code="OK",method="/todolist.Todo/GetListTask"} 1`,
+		`todolist_grpc_request_duration_seconds_count{method="/todolist.Todo/GetListTask"} 1`,
+		`todolist_grpc_panics_total{method="/todolist.Todo/CreateTask"} 1`,
+		`todolist_db_query_duration_seconds_count{error="true",method="GetAllTaskDB",repository="todo"} 1`,
+		`go_sql_open_connections{db_name="todo-db"}`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("scrape is missing %s", want)
+		}
+	}
+}
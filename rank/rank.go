@@ -0,0 +1,103 @@
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+// digits are ordered by their byte value so that ranks sort correctly with a binary collation.
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+var (
+	ErrInvalidRange = errors.New("rank: lower bound must sort before upper bound")
+	ErrNoRankBefore = errors.New("rank: nothing sorts before a rank of only zeros")
+)
+
+// Between returns a rank that sorts strictly between lower and upper. An empty lower
+// means "before everything" and an empty upper means "after everything", so
+// Between("", "") yields a rank for the first item of an empty list.
+func Between(lower, upper string) (string, error) {
+	if upper != "" && lower >= upper {
+		return "", ErrInvalidRange
+	}
+
+	var result strings.Builder
+	bounded := upper != ""
+	for i := 0; ; i++ {
+		lo := digitAt(lower, i, 0)
+
+		hi := base
+		if bounded {
+			hi = digitAt(upper, i, 0)
+		}
+
+		if hi-lo > 1 {
+			result.WriteByte(digits[(lo+hi)/2])
+			return result.String(), nil
+		}
+
+		result.WriteByte(digits[lo])
+
+		// once the prefix sorts below upper, any suffix keeps it there
+		if hi-lo == 1 {
+			bounded = false
+		}
+	}
+}
+
+func digitAt(rank string, i int, fallback int) int {
+	if i >= len(rank) {
+		return fallback
+	}
+
+	d := strings.IndexByte(digits, rank[i])
+	if d < 0 {
+		return fallback
+	}
+
+	return d
+}
+
+// After returns a rank that sorts after last, for appending to a list. Unlike Between with an
+// empty upper bound, it counts up in the last digit, carrying like a number, so that the rank
+// stays short. Once every digit is the highest one it doubles the length, which keeps the rank
+// of the n-th append at O(log n) digits.
+func After(last string) string {
+	if last == "" {
+		return digits[base/2 : base/2+1]
+	}
+
+	for i := len(last) - 1; i >= 0; i-- {
+		if d := digitAt(last, i, base-1); d < base-1 {
+			return last[:i] + string(digits[d+1]) + strings.Repeat(digits[:1], len(last)-i-1)
+		}
+	}
+
+	return last + strings.Repeat(digits[:1], len(last)-1) + digits[1:2]
+}
+
+// Before returns a rank that sorts before first, for prepending to a list. It counts down like
+// After counts up, and doubles the length rather than reaching a rank of only zeros, below which
+// nothing sorts.
+func Before(first string) (string, error) {
+	if first == "" {
+		return After(""), nil
+	}
+
+	i := len(first) - 1
+	for i >= 0 && digitAt(first, i, 0) == 0 {
+		i--
+	}
+	if i < 0 {
+		return "", ErrNoRankBefore
+	}
+
+	lowered := first[:i] + string(digits[digitAt(first, i, 0)-1]) + strings.Repeat(digits[base-1:], len(first)-i-1)
+	if strings.Trim(lowered, digits[:1]) == "" {
+		return strings.Repeat(digits[:1], len(first)) + strings.Repeat(digits[base-1:], len(first)), nil
+	}
+
+	return lowered, nil
+}
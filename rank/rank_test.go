@@ -0,0 +1,214 @@
+package rank
+
+import (
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	type args struct {
+		lower string
+		upper string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Empty List",
+			args: args{},
+			want: "V",
+		},
+		{
+			name: "After Last",
+			args: args{
+				lower: "V",
+			},
+			want: "k",
+		},
+		{
+			name: "Before First",
+			args: args{
+				upper: "V",
+			},
+			want: "F",
+		},
+		{
+			name: "Adjacent Digits",
+			args: args{
+				lower: "a",
+				upper: "b",
+			},
+			want: "aV",
+		},
+		{
+			name: "Upper Is Prefix Extension",
+			args: args{
+				lower: "a",
+				upper: "a1",
+			},
+			want: "a0V",
+		},
+		{
+			name: "Lower Not Below Upper",
+			args: args{
+				lower: "b",
+				upper: "a",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.args.lower, tt.args.upper)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Between() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Between() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetween_RepeatedInsertKeepsOrder(t *testing.T) {
+	lower, upper := "a", "b"
+	for i := 0; i < 200; i++ {
+		mid, err := Between(lower, upper)
+		if err != nil {
+			t.Fatalf("Between(%q, %q) error = %v", lower, upper, err)
+		}
+		if !(lower < mid && mid < upper) {
+			t.Fatalf("Between(%q, %q) = %q, not strictly between", lower, upper, mid)
+		}
+
+		if i%2 == 0 {
+			lower = mid
+		} else {
+			upper = mid
+		}
+	}
+}
+
+func TestAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		last string
+		want string
+	}{
+		{
+			name: "Empty List",
+			last: "",
+			want: "V",
+		},
+		{
+			name: "Increments Last Digit",
+			last: "V",
+			want: "W",
+		},
+		{
+			name: "Carries",
+			last: "Vz",
+			want: "W0",
+		},
+		{
+			name: "Migrated Rank",
+			last: "00000000000000000042",
+			want: "00000000000000000043",
+		},
+		{
+			name: "Doubles When Full",
+			last: "zz",
+			want: "zz01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := After(tt.last); got != tt.want {
+				t.Errorf("After() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBefore(t *testing.T) {
+	tests := []struct {
+		name    string
+		first   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Empty List",
+			first: "",
+			want:  "V",
+		},
+		{
+			name:  "Decrements Last Digit",
+			first: "V",
+			want:  "U",
+		},
+		{
+			name:  "Borrows",
+			first: "W0",
+			want:  "Vz",
+		},
+		{
+			name:  "Doubles Before Only Zeros",
+			first: "01",
+			want:  "00zz",
+		},
+		{
+			name:    "Only Zeros",
+			first:   "00",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Before(tt.first)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Before() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Before() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAfter_RepeatedAppendStaysShort(t *testing.T) {
+	last := ""
+	for i := 0; i < 10000; i++ {
+		next := After(last)
+		if next <= last {
+			t.Fatalf("After(%q) = %q, does not sort after it", last, next)
+		}
+		last = next
+	}
+
+	if len(last) > 8 {
+		t.Errorf("rank after 10000 appends = %q, want at most 8 digits", last)
+	}
+}
+
+func TestBefore_RepeatedPrependStaysShort(t *testing.T) {
+	first := ""
+	for i := 0; i < 10000; i++ {
+		next, err := Before(first)
+		if err != nil {
+			t.Fatalf("Before(%q) error = %v", first, err)
+		}
+		if first != "" && next >= first {
+			t.Fatalf("Before(%q) = %q, does not sort before it", first, next)
+		}
+		first = next
+	}
+
+	if len(first) > 8 {
+		t.Errorf("rank after 10000 prepends = %q, want at most 8 digits", first)
+	}
+}
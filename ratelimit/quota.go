@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Quota caps the number of calls per key over a fixed window of Period, starting at the first
+// call of the key. Unlike a Limiter it does not refill gradually: once Limit calls were made,
+// the key is refused until its window ends. Windows are kept in memory, so every server
+// instance counts separately.
+type Quota struct {
+	Limit  int
+	Period time.Duration
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+type window struct {
+	start time.Time
+	used  int
+}
+
+func NewQuota(limit int, period time.Duration) *Quota {
+	return &Quota{
+		Limit:   limit,
+		Period:  period,
+		windows: make(map[string]*window),
+	}
+}
+
+// Allow counts a call of key against its quota. When the quota is used up it returns false and
+// how long until the window of key ends.
+func (q *Quota) Allow(key string) (ok bool, retryAfter time.Duration) {
+	now := time.Now()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.sweep(now)
+
+	w, found := q.windows[key]
+	if !found || !now.Before(w.start.Add(q.Period)) {
+		w = &window{start: now}
+		q.windows[key] = w
+	}
+
+	if w.used >= q.Limit {
+		return false, w.start.Add(q.Period).Sub(now)
+	}
+
+	w.used++
+	return true, 0
+}
+
+// sweep drops the windows that ended, a new call starts a fresh one anyway.
+func (q *Quota) sweep(now time.Time) {
+	if now.Sub(q.lastSweep) < idleSweepInterval {
+		return
+	}
+
+	q.lastSweep = now
+	for key, w := range q.windows {
+		if !now.Before(w.start.Add(q.Period)) {
+			delete(q.windows, key)
+		}
+	}
+}
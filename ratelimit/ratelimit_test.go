@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	monkey.Patch(time.Now, func() time.Time {
+		return now
+	})
+	defer monkey.UnpatchAll()
+
+	limiter := NewLimiter(2, 3)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.Allow("alice"); !ok {
+			t.Fatalf("Allow() call %d = false, want burst of 3", i+1)
+		}
+	}
+
+	ok, retryAfter := limiter.Allow("alice")
+	if ok {
+		t.Fatalf("Allow() = true after burst, want false")
+	}
+	if retryAfter != 500*time.Millisecond {
+		t.Errorf("Allow() retryAfter = %v, want %v", retryAfter, 500*time.Millisecond)
+	}
+
+	if ok, _ := limiter.Allow("bob"); !ok {
+		t.Errorf("Allow() for another key = false, want its own bucket")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := limiter.Allow("alice"); !ok {
+		t.Errorf("Allow() after refill = false, want true")
+	}
+	if ok, _ := limiter.Allow("alice"); ok {
+		t.Errorf("Allow() = true with one refilled token used, want false")
+	}
+}
+
+func TestLimiter_SweepsFullBuckets(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	monkey.Patch(time.Now, func() time.Time {
+		return now
+	})
+	defer monkey.UnpatchAll()
+
+	limiter := NewLimiter(1, 1)
+	limiter.Allow("alice")
+
+	now = now.Add(2 * idleSweepInterval)
+	limiter.Allow("bob")
+
+	if _, found := limiter.buckets["alice"]; found {
+		t.Errorf("bucket of idle key was not swept")
+	}
+}
+
+func TestQuota_Allow(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	monkey.Patch(time.Now, func() time.Time {
+		return now
+	})
+	defer monkey.UnpatchAll()
+
+	quota := NewQuota(2, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := quota.Allow("alice"); !ok {
+			t.Fatalf("Allow() call %d = false, want a quota of 2", i+1)
+		}
+	}
+
+	now = now.Add(15 * time.Minute)
+	ok, retryAfter := quota.Allow("alice")
+	if ok {
+		t.Fatalf("Allow() = true with the quota used up, want false")
+	}
+	if retryAfter != 45*time.Minute {
+		t.Errorf("Allow() retryAfter = %v, want %v", retryAfter, 45*time.Minute)
+	}
+
+	if ok, _ := quota.Allow("bob"); !ok {
+		t.Errorf("Allow() for another key = false, want its own quota")
+	}
+
+	now = now.Add(45 * time.Minute)
+	if ok, _ := quota.Allow("alice"); !ok {
+		t.Errorf("Allow() in the next window = false, want true")
+	}
+}
+
+func TestQuota_SweepsEndedWindows(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	monkey.Patch(time.Now, func() time.Time {
+		return now
+	})
+	defer monkey.UnpatchAll()
+
+	quota := NewQuota(1, time.Minute)
+	quota.Allow("alice")
+
+	now = now.Add(2 * idleSweepInterval)
+	quota.Allow("bob")
+
+	if _, found := quota.windows["alice"]; found {
+		t.Errorf("ended window was not swept")
+	}
+}
@@ -0,0 +1,156 @@
+package indexed
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/winartodev/go-grpc/auth"
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	"github.com/winartodev/go-grpc/search"
+	"github.com/winartodev/go-grpc/types"
+)
+
+// TodoRepository wraps a backend without full-text support and answers SearchTaskDB from
+// in-process inverted indexes, one per tenant, which it keeps in sync with every write going
+// through it. A tenant's index is built from the backend on its first search.
+type TodoRepository struct {
+	todoRepository.TodoRepositoryInterface
+
+	mu      sync.Mutex
+	indexes map[string]*search.Index
+}
+
+// NewTodoRepository builds the index of the tenant in ctx upfront, so a broken backend fails at startup.
+func NewTodoRepository(ctx context.Context, repository todoRepository.TodoRepositoryInterface) (todoRepository.TodoRepositoryInterface, error) {
+	tr := &TodoRepository{
+		TodoRepositoryInterface: repository,
+		indexes:                 make(map[string]*search.Index),
+	}
+
+	_, err := tr.index(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tr, nil
+}
+
+func (tr *TodoRepository) Create(ctx context.Context, data types.Task) (id int64, err error) {
+	id, err = tr.TodoRepositoryInterface.Create(ctx, data)
+	if err != nil {
+		return id, err
+	}
+
+	data.ID = id
+	tr.update(ctx, func(index *search.Index) {
+		index.Add(data)
+	})
+
+	return id, nil
+}
+
+func (tr *TodoRepository) SearchTaskDB(ctx context.Context, query search.Query, limit int) (result []types.TaskSearchResult, err error) {
+	index, err := tr.index(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.Search(query, limit), nil
+}
+
+func (tr *TodoRepository) UpdateByIDDB(ctx context.Context, id int64, data types.Task) (err error) {
+	err = tr.TodoRepositoryInterface.UpdateByIDDB(ctx, id, data)
+	if err != nil {
+		return err
+	}
+
+	data.ID = id
+	tr.update(ctx, func(index *search.Index) {
+		index.Add(data)
+	})
+
+	return nil
+}
+
+func (tr *TodoRepository) UpdateRankByIDDB(ctx context.Context, id int64, rank string, updatedAt *time.Time) (err error) {
+	err = tr.TodoRepositoryInterface.UpdateRankByIDDB(ctx, id, rank, updatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tr.reindex(ctx, id)
+}
+
+func (tr *TodoRepository) UpdateAssigneeByIDDB(ctx context.Context, id int64, assigneeID string, updatedAt *time.Time) (err error) {
+	err = tr.TodoRepositoryInterface.UpdateAssigneeByIDDB(ctx, id, assigneeID, updatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tr.reindex(ctx, id)
+}
+
+func (tr *TodoRepository) DeleteByIDDB(ctx context.Context, id int64) (err error) {
+	err = tr.TodoRepositoryInterface.DeleteByIDDB(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	tr.update(ctx, func(index *search.Index) {
+		index.Remove(id)
+	})
+
+	return nil
+}
+
+// reindex refreshes the indexed copy of a task after a partial update.
+func (tr *TodoRepository) reindex(ctx context.Context, id int64) (err error) {
+	task, err := tr.TodoRepositoryInterface.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	tr.update(ctx, func(index *search.Index) {
+		index.Add(*task)
+	})
+
+	return nil
+}
+
+// index returns the index of the tenant in ctx, loading it from the backend when needed.
+// The lock is held while loading so no write can slip between the load and the first update.
+func (tr *TodoRepository) index(ctx context.Context) (*search.Index, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tenantID := auth.TenantFromContext(ctx)
+	if index, ok := tr.indexes[tenantID]; ok {
+		return index, nil
+	}
+
+	tasks, err := tr.TodoRepositoryInterface.GetAllTaskDB(ctx, types.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	index := search.NewIndex()
+	for _, task := range tasks {
+		index.Add(task)
+	}
+
+	tr.indexes[tenantID] = index
+
+	return index, nil
+}
+
+// update applies a write to the tenant's index when it was already loaded, otherwise the
+// write is picked up by the initial load.
+func (tr *TodoRepository) update(ctx context.Context, apply func(index *search.Index)) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if index, ok := tr.indexes[auth.TenantFromContext(ctx)]; ok {
+		apply(index)
+	}
+}
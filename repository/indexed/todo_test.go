@@ -0,0 +1,111 @@
+package indexed
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/winartodev/go-grpc/auth"
+	todoRepositoryMock "github.com/winartodev/go-grpc/repository/mysql/mocks"
+	"github.com/winartodev/go-grpc/search"
+	"github.com/winartodev/go-grpc/types"
+)
+
+func TestTodoRepository_KeepsIndexInSync(t *testing.T) {
+	ctx := context.Background()
+	repository := new(todoRepositoryMock.TodoRepositoryInterface)
+
+	existing := types.Task{ID: 1, Description: "buy milk"}
+	created := types.Task{Description: "write the report"}
+	updated := types.Task{Description: "buy oat milk"}
+
+	repository.On("GetAllTaskDB", ctx, types.TaskFilter{}).Return([]types.Task{existing}, nil)
+	repository.On("Create", ctx, created).Return(int64(2), nil)
+	repository.On("UpdateByIDDB", ctx, int64(1), updated).Return(nil)
+	repository.On("DeleteByIDDB", ctx, int64(2)).Return(nil)
+
+	now := time.Now()
+	repository.On("UpdateRankByIDDB", ctx, int64(1), "aV", &now).Return(nil)
+	repository.On("GetByID", ctx, int64(1)).Return(&types.Task{ID: 1, Description: "buy oat milk", Rank: "aV"}, nil)
+
+	tr, err := NewTodoRepository(ctx, repository)
+	if err != nil {
+		t.Fatalf("NewTodoRepository() error = %v", err)
+	}
+
+	searchIDs := func(query string) (ids []int64) {
+		results, err := tr.SearchTaskDB(ctx, search.ParseQuery(query), 0)
+		if err != nil {
+			t.Fatalf("TodoRepository.SearchTaskDB() error = %v", err)
+		}
+		for _, result := range results {
+			ids = append(ids, result.Task.ID)
+		}
+		return ids
+	}
+
+	if got := searchIDs("milk"); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("search after load = %v, want %v", got, []int64{1})
+	}
+
+	if _, err := tr.Create(ctx, created); err != nil {
+		t.Fatalf("TodoRepository.Create() error = %v", err)
+	}
+	if got := searchIDs("report"); !reflect.DeepEqual(got, []int64{2}) {
+		t.Errorf("search after create = %v, want %v", got, []int64{2})
+	}
+
+	if err := tr.UpdateByIDDB(ctx, 1, updated); err != nil {
+		t.Fatalf("TodoRepository.UpdateByIDDB() error = %v", err)
+	}
+	if got := searchIDs(`"oat milk"`); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("search after update = %v, want %v", got, []int64{1})
+	}
+
+	if err := tr.UpdateRankByIDDB(ctx, 1, "aV", &now); err != nil {
+		t.Fatalf("TodoRepository.UpdateRankByIDDB() error = %v", err)
+	}
+	results, _ := tr.SearchTaskDB(ctx, search.ParseQuery("milk"), 0)
+	if len(results) != 1 || results[0].Task.Rank != "aV" {
+		t.Errorf("search after move = %v, want the task with its new rank", results)
+	}
+
+	if err := tr.DeleteByIDDB(ctx, 2); err != nil {
+		t.Fatalf("TodoRepository.DeleteByIDDB() error = %v", err)
+	}
+	if got := searchIDs("report"); got != nil {
+		t.Errorf("search after delete = %v, want no results", got)
+	}
+
+	repository.AssertExpectations(t)
+}
+
+func TestTodoRepository_SeparatesTenants(t *testing.T) {
+	acme := auth.NewContext(context.Background(), auth.Principal{UserID: "alice", TenantID: "acme"})
+	globex := auth.NewContext(context.Background(), auth.Principal{UserID: "mallory", TenantID: "globex"})
+	repository := new(todoRepositoryMock.TodoRepositoryInterface)
+
+	repository.On("GetAllTaskDB", acme, types.TaskFilter{}).Return([]types.Task{{ID: 1, Description: "acme secret plan"}}, nil).Once()
+	repository.On("GetAllTaskDB", globex, types.TaskFilter{}).Return([]types.Task{{ID: 2, Description: "globex plan"}}, nil).Once()
+
+	tr, err := NewTodoRepository(acme, repository)
+	if err != nil {
+		t.Fatalf("NewTodoRepository() error = %v", err)
+	}
+
+	results, err := tr.SearchTaskDB(globex, search.ParseQuery("plan"), 0)
+	if err != nil {
+		t.Fatalf("TodoRepository.SearchTaskDB() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Task.ID != 2 {
+		t.Errorf("globex search = %v, want only task 2", results)
+	}
+
+	results, _ = tr.SearchTaskDB(acme, search.ParseQuery("plan"), 0)
+	if len(results) != 1 || results[0].Task.ID != 1 {
+		t.Errorf("acme search = %v, want only task 1", results)
+	}
+
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,46 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	"github.com/winartodev/go-grpc/types"
+)
+
+type APIKeyRepository struct {
+	Repository todoRepository.APIKeyRepositoryInterface
+	instrument
+}
+
+func NewAPIKeyRepository(repository todoRepository.APIKeyRepositoryInterface, observer QueryObserver) todoRepository.APIKeyRepositoryInterface {
+	return &APIKeyRepository{
+		Repository: repository,
+		instrument: instrument{observer: observer, repository: "api_key"},
+	}
+}
+
+func (ar *APIKeyRepository) Create(ctx context.Context, data types.APIKey) (id int64, err error) {
+	defer ar.observe("Create", time.Now(), &err)
+	return ar.Repository.Create(ctx, data)
+}
+
+func (ar *APIKeyRepository) GetByID(ctx context.Context, id int64) (result *types.APIKey, err error) {
+	defer ar.observe("GetByID", time.Now(), &err)
+	return ar.Repository.GetByID(ctx, id)
+}
+
+func (ar *APIKeyRepository) GetByHashDB(ctx context.Context, hash string) (result *types.APIKey, err error) {
+	defer ar.observe("GetByHashDB", time.Now(), &err)
+	return ar.Repository.GetByHashDB(ctx, hash)
+}
+
+func (ar *APIKeyRepository) RevokeByIDDB(ctx context.Context, id int64, revokedAt *time.Time) (err error) {
+	defer ar.observe("RevokeByIDDB", time.Now(), &err)
+	return ar.Repository.RevokeByIDDB(ctx, id, revokedAt)
+}
+
+func (ar *APIKeyRepository) UpdateLastUsedDB(ctx context.Context, id int64, usedAt *time.Time, ip string) (err error) {
+	defer ar.observe("UpdateLastUsedDB", time.Now(), &err)
+	return ar.Repository.UpdateLastUsedDB(ctx, id, usedAt, ip)
+}
@@ -0,0 +1,41 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	"github.com/winartodev/go-grpc/types"
+)
+
+type AttachmentRepository struct {
+	Repository todoRepository.AttachmentRepositoryInterface
+	instrument
+}
+
+func NewAttachmentRepository(repository todoRepository.AttachmentRepositoryInterface, observer QueryObserver) todoRepository.AttachmentRepositoryInterface {
+	return &AttachmentRepository{
+		Repository: repository,
+		instrument: instrument{observer: observer, repository: "attachment"},
+	}
+}
+
+func (ar *AttachmentRepository) Create(ctx context.Context, data types.Attachment) (id int64, err error) {
+	defer ar.observe("Create", time.Now(), &err)
+	return ar.Repository.Create(ctx, data)
+}
+
+func (ar *AttachmentRepository) GetByID(ctx context.Context, id int64) (result *types.Attachment, err error) {
+	defer ar.observe("GetByID", time.Now(), &err)
+	return ar.Repository.GetByID(ctx, id)
+}
+
+func (ar *AttachmentRepository) GetByTaskIDDB(ctx context.Context, taskID int64) (result []types.Attachment, err error) {
+	defer ar.observe("GetByTaskIDDB", time.Now(), &err)
+	return ar.Repository.GetByTaskIDDB(ctx, taskID)
+}
+
+func (ar *AttachmentRepository) DeleteByIDDB(ctx context.Context, id int64) (err error) {
+	defer ar.observe("DeleteByIDDB", time.Now(), &err)
+	return ar.Repository.DeleteByIDDB(ctx, id)
+}
@@ -0,0 +1,21 @@
+// Package instrumented wraps the repositories to time every method, so query latency is
+// visible per repository method without touching the SQL code.
+package instrumented
+
+import (
+	"time"
+)
+
+type QueryObserver interface {
+	ObserveQuery(repository string, method string, duration time.Duration, err error)
+}
+
+type instrument struct {
+	observer   QueryObserver
+	repository string
+}
+
+// observe is meant to be deferred with the start time and the address of the named error result.
+func (i instrument) observe(method string, start time.Time, err *error) {
+	i.observer.ObserveQuery(i.repository, method, time.Since(start), *err)
+}
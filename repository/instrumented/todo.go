@@ -0,0 +1,72 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	"github.com/winartodev/go-grpc/search"
+	"github.com/winartodev/go-grpc/types"
+)
+
+type TodoRepository struct {
+	Repository todoRepository.TodoRepositoryInterface
+	instrument
+}
+
+func NewTodoRepository(repository todoRepository.TodoRepositoryInterface, observer QueryObserver) todoRepository.TodoRepositoryInterface {
+	return &TodoRepository{
+		Repository: repository,
+		instrument: instrument{observer: observer, repository: "todo"},
+	}
+}
+
+func (tr *TodoRepository) Create(ctx context.Context, data types.Task) (id int64, err error) {
+	defer tr.observe("Create", time.Now(), &err)
+	return tr.Repository.Create(ctx, data)
+}
+
+func (tr *TodoRepository) GetByID(ctx context.Context, id int64) (result *types.Task, err error) {
+	defer tr.observe("GetByID", time.Now(), &err)
+	return tr.Repository.GetByID(ctx, id)
+}
+
+func (tr *TodoRepository) GetAllTaskDB(ctx context.Context, filter types.TaskFilter) (result []types.Task, err error) {
+	defer tr.observe("GetAllTaskDB", time.Now(), &err)
+	return tr.Repository.GetAllTaskDB(ctx, filter)
+}
+
+func (tr *TodoRepository) SearchTaskDB(ctx context.Context, query search.Query, limit int) (result []types.TaskSearchResult, err error) {
+	defer tr.observe("SearchTaskDB", time.Now(), &err)
+	return tr.Repository.SearchTaskDB(ctx, query, limit)
+}
+
+func (tr *TodoRepository) GetLastRankDB(ctx context.Context) (rank string, err error) {
+	defer tr.observe("GetLastRankDB", time.Now(), &err)
+	return tr.Repository.GetLastRankDB(ctx)
+}
+
+func (tr *TodoRepository) GetAdjacentRankDB(ctx context.Context, rank string, next bool, excludeID int64) (result string, err error) {
+	defer tr.observe("GetAdjacentRankDB", time.Now(), &err)
+	return tr.Repository.GetAdjacentRankDB(ctx, rank, next, excludeID)
+}
+
+func (tr *TodoRepository) UpdateByIDDB(ctx context.Context, id int64, data types.Task) (err error) {
+	defer tr.observe("UpdateByIDDB", time.Now(), &err)
+	return tr.Repository.UpdateByIDDB(ctx, id, data)
+}
+
+func (tr *TodoRepository) UpdateRankByIDDB(ctx context.Context, id int64, rank string, updatedAt *time.Time) (err error) {
+	defer tr.observe("UpdateRankByIDDB", time.Now(), &err)
+	return tr.Repository.UpdateRankByIDDB(ctx, id, rank, updatedAt)
+}
+
+func (tr *TodoRepository) UpdateAssigneeByIDDB(ctx context.Context, id int64, assigneeID string, updatedAt *time.Time) (err error) {
+	defer tr.observe("UpdateAssigneeByIDDB", time.Now(), &err)
+	return tr.Repository.UpdateAssigneeByIDDB(ctx, id, assigneeID, updatedAt)
+}
+
+func (tr *TodoRepository) DeleteByIDDB(ctx context.Context, id int64) (err error) {
+	defer tr.observe("DeleteByIDDB", time.Now(), &err)
+	return tr.Repository.DeleteByIDDB(ctx, id)
+}
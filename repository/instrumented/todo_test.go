@@ -0,0 +1,55 @@
+package instrumented
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	todoRepositoryMock "github.com/winartodev/go-grpc/repository/mysql/mocks"
+	"github.com/winartodev/go-grpc/types"
+)
+
+type observation struct {
+	repository string
+	method     string
+	err        error
+}
+
+type fakeObserver struct {
+	observations []observation
+}
+
+func (f *fakeObserver) ObserveQuery(repository string, method string, duration time.Duration, err error) {
+	f.observations = append(f.observations, observation{repository, method, err})
+}
+
+func TestTodoRepository_ObservesQueries(t *testing.T) {
+	ctx := context.Background()
+	repository := new(todoRepositoryMock.TodoRepositoryInterface)
+	repository.On("GetByID", ctx, int64(1)).Return(&types.Task{ID: 1}, nil)
+	repository.On("DeleteByIDDB", ctx, int64(2)).Return(sql.ErrConnDone)
+
+	observer := &fakeObserver{}
+	tr := NewTodoRepository(repository, observer)
+
+	if task, err := tr.GetByID(ctx, 1); err != nil || task.ID != 1 {
+		t.Fatalf("TodoRepository.GetByID() = %v, %v", task, err)
+	}
+	if err := tr.DeleteByIDDB(ctx, 2); err != sql.ErrConnDone {
+		t.Fatalf("TodoRepository.DeleteByIDDB() error = %v, want %v", err, sql.ErrConnDone)
+	}
+
+	want := []observation{
+		{"todo", "GetByID", nil},
+		{"todo", "DeleteByIDDB", sql.ErrConnDone},
+	}
+	if len(observer.observations) != len(want) {
+		t.Fatalf("observations = %v, want %v", observer.observations, want)
+	}
+	for i := range want {
+		if observer.observations[i] != want[i] {
+			t.Errorf("observation %d = %v, want %v", i, observer.observations[i], want[i])
+		}
+	}
+}
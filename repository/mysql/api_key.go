@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/types"
+)
+
+// APIKeyRepository scopes keys by the tenant of the caller, except GetByHashDB which runs
+// before the caller is known and returns the tenant of the key.
+type APIKeyRepository struct {
+	DB *sql.DB
+}
+
+type APIKeyRepositoryInterface interface {
+	Create(ctx context.Context, data types.APIKey) (id int64, err error)
+	GetByID(ctx context.Context, id int64) (result *types.APIKey, err error)
+	GetByHashDB(ctx context.Context, hash string) (result *types.APIKey, err error)
+	RevokeByIDDB(ctx context.Context, id int64, revokedAt *time.Time) (err error)
+	UpdateLastUsedDB(ctx context.Context, id int64, usedAt *time.Time, ip string) (err error)
+}
+
+func NewAPIKeyRepository(db *sql.DB) APIKeyRepositoryInterface {
+	return &APIKeyRepository{
+		DB: db,
+	}
+}
+
+func (ar *APIKeyRepository) Create(ctx context.Context, data types.APIKey) (id int64, err error) {
+	stmt, err := ar.DB.PrepareContext(ctx, CreateAPIKeyQuery)
+	if err != nil {
+		return id, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, auth.TenantFromContext(ctx), data.Name, data.Prefix, data.Hash, strings.Join(data.Scopes, ","), data.CreatorID, data.ExpiresAt, data.CreatedAt)
+	if err != nil {
+		return id, err
+	}
+
+	return res.LastInsertId()
+}
+
+func (ar *APIKeyRepository) GetByID(ctx context.Context, id int64) (result *types.APIKey, err error) {
+	return ar.scan(ar.DB.QueryRowContext(ctx, GetAPIKeyByID, auth.TenantFromContext(ctx), id))
+}
+
+func (ar *APIKeyRepository) GetByHashDB(ctx context.Context, hash string) (result *types.APIKey, err error) {
+	return ar.scan(ar.DB.QueryRowContext(ctx, GetAPIKeyByHash, hash))
+}
+
+func (ar *APIKeyRepository) RevokeByIDDB(ctx context.Context, id int64, revokedAt *time.Time) (err error) {
+	stmt, err := ar.DB.PrepareContext(ctx, RevokeAPIKeyQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, revokedAt, auth.TenantFromContext(ctx), id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *APIKeyRepository) UpdateLastUsedDB(ctx context.Context, id int64, usedAt *time.Time, ip string) (err error) {
+	stmt, err := ar.DB.PrepareContext(ctx, UpdateAPIKeyLastUsedQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, usedAt, ip, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ar *APIKeyRepository) scan(row *sql.Row) (result *types.APIKey, err error) {
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var apiKey types.APIKey
+	var scopes string
+	err = row.Scan(&apiKey.ID, &apiKey.TenantID, &apiKey.Name, &apiKey.Prefix, &apiKey.Hash, &scopes, &apiKey.CreatorID, &apiKey.ExpiresAt, &apiKey.RevokedAt, &apiKey.LastUsedAt, &apiKey.LastUsedIP, &apiKey.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if scopes != "" {
+		apiKey.Scopes = strings.Split(scopes, ",")
+	}
+
+	return &apiKey, nil
+}
@@ -0,0 +1,13 @@
+package mysql
+
+var (
+	CreateAPIKeyQuery = `INSERT INTO api_key (id, tenant_id, name, prefix, key_hash, scopes, creator_id, expires_at, created_at) VALUES (NULL, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	GetAPIKeyByID = `SELECT id, tenant_id, name, prefix, key_hash, scopes, creator_id, expires_at, revoked_at, last_used_at, last_used_ip, created_at FROM api_key WHERE tenant_id = ? AND id = ?;`
+
+	GetAPIKeyByHash = `SELECT id, tenant_id, name, prefix, key_hash, scopes, creator_id, expires_at, revoked_at, last_used_at, last_used_ip, created_at FROM api_key WHERE key_hash = ?;`
+
+	RevokeAPIKeyQuery = `UPDATE api_key SET revoked_at = ? WHERE tenant_id = ? AND id = ?;`
+
+	UpdateAPIKeyLastUsedQuery = `UPDATE api_key SET last_used_at = ?, last_used_ip = ? WHERE id = ?;`
+)
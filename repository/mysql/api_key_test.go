@@ -0,0 +1,187 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/types"
+)
+
+var (
+	apiKeyDataMock = types.APIKey{
+		ID:        1,
+		TenantID:  "acme",
+		Name:      "nightly export",
+		Prefix:    "tk_0123abcd",
+		Hash:      "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		Scopes:    []string{"read", "create"},
+		CreatorID: "alice",
+		CreatedAt: &mockTime,
+	}
+
+	apiKeyColumns = []string{"id", "tenant_id", "name", "prefix", "key_hash", "scopes", "creator_id", "expires_at", "revoked_at", "last_used_at", "last_used_ip", "created_at"}
+)
+
+func apiKeyRow(dbmock sqlmock.Sqlmock) *sqlmock.Rows {
+	return dbmock.NewRows(apiKeyColumns).
+		AddRow(apiKeyDataMock.ID, apiKeyDataMock.TenantID, apiKeyDataMock.Name, apiKeyDataMock.Prefix, apiKeyDataMock.Hash, "read,create", apiKeyDataMock.CreatorID, nil, nil, nil, "", apiKeyDataMock.CreatedAt)
+}
+
+func TestNewAPIKeyRepository(t *testing.T) {
+	db, _ := NewMock()
+
+	if got := NewAPIKeyRepository(db); !reflect.DeepEqual(got, &APIKeyRepository{DB: db}) {
+		t.Errorf("NewAPIKeyRepository() = %v, want %v", got, &APIKeyRepository{DB: db})
+	}
+}
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	dbmock.ExpectPrepare(regexp.QuoteMeta(CreateAPIKeyQuery)).
+		ExpectExec().
+		WithArgs(principalMock.TenantID, apiKeyDataMock.Name, apiKeyDataMock.Prefix, apiKeyDataMock.Hash, "read,create", apiKeyDataMock.CreatorID, apiKeyDataMock.ExpiresAt, apiKeyDataMock.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	ar := &APIKeyRepository{
+		DB: db,
+	}
+	gotID, err := ar.Create(ctx, apiKeyDataMock)
+	if err != nil {
+		t.Fatalf("APIKeyRepository.Create() error = %v", err)
+	}
+	if gotID != 1 {
+		t.Errorf("APIKeyRepository.Create() = %v, want %v", gotID, 1)
+	}
+}
+
+func TestAPIKeyRepository_GetByID(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+	otherCtx := auth.NewContext(context.Background(), otherTenantMock)
+
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult *types.APIKey
+		wantErr    error
+		mock       func()
+	}{
+		{
+			name: "Success Get API Key By ID",
+			args: args{
+				ctx: ctx,
+				id:  1,
+			},
+			wantResult: &apiKeyDataMock,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(GetAPIKeyByID)).
+					WithArgs(principalMock.TenantID, int64(1)).
+					WillReturnRows(apiKeyRow(dbmock))
+			},
+		},
+		{
+			name: "Other Tenant",
+			args: args{
+				ctx: otherCtx,
+				id:  1,
+			},
+			wantErr: sql.ErrNoRows,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(GetAPIKeyByID)).
+					WithArgs(otherTenantMock.TenantID, int64(1)).
+					WillReturnRows(dbmock.NewRows(apiKeyColumns))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			ar := &APIKeyRepository{
+				DB: db,
+			}
+			gotResult, err := ar.GetByID(tt.args.ctx, tt.args.id)
+			if err != tt.wantErr {
+				t.Errorf("APIKeyRepository.GetByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("APIKeyRepository.GetByID() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRepository_GetByHashDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := context.Background()
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(GetAPIKeyByHash)).
+		WithArgs(apiKeyDataMock.Hash).
+		WillReturnRows(apiKeyRow(dbmock))
+
+	ar := &APIKeyRepository{
+		DB: db,
+	}
+	gotResult, err := ar.GetByHashDB(ctx, apiKeyDataMock.Hash)
+	if err != nil {
+		t.Fatalf("APIKeyRepository.GetByHashDB() error = %v", err)
+	}
+	if !reflect.DeepEqual(gotResult, &apiKeyDataMock) {
+		t.Errorf("APIKeyRepository.GetByHashDB() = %v, want %v", gotResult, &apiKeyDataMock)
+	}
+}
+
+func TestAPIKeyRepository_RevokeByIDDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	dbmock.ExpectPrepare(regexp.QuoteMeta(RevokeAPIKeyQuery)).
+		WillBeClosed().
+		ExpectExec().
+		WithArgs(&mockTime, principalMock.TenantID, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ar := &APIKeyRepository{
+		DB: db,
+	}
+	if err := ar.RevokeByIDDB(ctx, 1, &mockTime); err != nil {
+		t.Errorf("APIKeyRepository.RevokeByIDDB() error = %v", err)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAPIKeyRepository_UpdateLastUsedDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := context.Background()
+
+	dbmock.ExpectPrepare(regexp.QuoteMeta(UpdateAPIKeyLastUsedQuery)).
+		WillBeClosed().
+		ExpectExec().
+		WithArgs(&mockTime, "10.0.0.7", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ar := &APIKeyRepository{
+		DB: db,
+	}
+	if err := ar.UpdateLastUsedDB(ctx, 1, &mockTime, "10.0.0.7"); err != nil {
+		t.Errorf("APIKeyRepository.UpdateLastUsedDB() error = %v", err)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
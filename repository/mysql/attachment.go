@@ -0,0 +1,98 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/types"
+)
+
+// AttachmentRepository scopes reads and deletes to the tenant of the caller in ctx through
+// the task of the attachment. Creates rely on the caller having looked up the task first.
+type AttachmentRepository struct {
+	DB *sql.DB
+}
+
+type AttachmentRepositoryInterface interface {
+	Create(ctx context.Context, data types.Attachment) (id int64, err error)
+	GetByID(ctx context.Context, id int64) (result *types.Attachment, err error)
+	GetByTaskIDDB(ctx context.Context, taskID int64) (result []types.Attachment, err error)
+	DeleteByIDDB(ctx context.Context, id int64) (err error)
+}
+
+func NewAttachmentRepository(db *sql.DB) AttachmentRepositoryInterface {
+	return &AttachmentRepository{
+		DB: db,
+	}
+}
+
+func (ar *AttachmentRepository) Create(ctx context.Context, data types.Attachment) (id int64, err error) {
+	stmt, err := ar.DB.PrepareContext(ctx, CreateAttachmentQuery)
+	if err != nil {
+		return id, err
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, data.TaskID, data.Name, data.Size, data.ContentType, data.SHA256, data.StorageKey, data.CreatedAt)
+	if err != nil {
+		return id, err
+	}
+
+	return res.LastInsertId()
+}
+
+func (ar *AttachmentRepository) GetByID(ctx context.Context, id int64) (result *types.Attachment, err error) {
+	row := ar.DB.QueryRowContext(ctx, GetAttachmentByID, auth.TenantFromContext(ctx), id)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var attachment types.Attachment
+	err = row.Scan(&attachment.ID, &attachment.TaskID, &attachment.Name, &attachment.Size, &attachment.ContentType, &attachment.SHA256, &attachment.StorageKey, &attachment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (ar *AttachmentRepository) GetByTaskIDDB(ctx context.Context, taskID int64) (result []types.Attachment, err error) {
+	rows, err := ar.DB.QueryContext(ctx, GetAttachmentByTaskID, auth.TenantFromContext(ctx), taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []types.Attachment
+	for rows.Next() {
+		var attachment types.Attachment
+		err := rows.Scan(&attachment.ID, &attachment.TaskID, &attachment.Name, &attachment.Size, &attachment.ContentType, &attachment.SHA256, &attachment.StorageKey, &attachment.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (ar *AttachmentRepository) DeleteByIDDB(ctx context.Context, id int64) (err error) {
+	stmt, err := ar.DB.PrepareContext(ctx, DeleteAttachmentQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, auth.TenantFromContext(ctx), id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
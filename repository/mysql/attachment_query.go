@@ -0,0 +1,12 @@
+package mysql
+
+// The attachments have no tenant of their own, they are scoped by joining the tenant of their task.
+var (
+	CreateAttachmentQuery = `INSERT INTO task_attachment (id, task_id, name, size, content_type, sha256, storage_key, created_at) VALUES (NULL, ?, ?, ?, ?, ?, ?, ?);`
+
+	GetAttachmentByID = `SELECT a.id, a.task_id, a.name, a.size, a.content_type, a.sha256, a.storage_key, a.created_at FROM task_attachment a JOIN task t ON t.id = a.task_id WHERE t.tenant_id = ? AND a.id = ?;`
+
+	GetAttachmentByTaskID = `SELECT a.id, a.task_id, a.name, a.size, a.content_type, a.sha256, a.storage_key, a.created_at FROM task_attachment a JOIN task t ON t.id = a.task_id WHERE t.tenant_id = ? AND a.task_id = ?;`
+
+	DeleteAttachmentQuery = `DELETE a FROM task_attachment a JOIN task t ON t.id = a.task_id WHERE t.tenant_id = ? AND a.id = ?;`
+)
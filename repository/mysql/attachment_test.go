@@ -0,0 +1,302 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/types"
+)
+
+var (
+	attachmentDataMock = types.Attachment{
+		ID:          1,
+		TaskID:      1,
+		Name:        "notes.txt",
+		Size:        5,
+		ContentType: "text/plain",
+		SHA256:      "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		StorageKey:  "1/abc",
+		CreatedAt:   &mockTime,
+	}
+
+	attachmentColumns = []string{"id", "task_id", "name", "size", "content_type", "sha256", "storage_key", "created_at"}
+)
+
+func TestNewAttachmentRepository(t *testing.T) {
+	db, _ := NewMock()
+
+	type args struct {
+		db *sql.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want AttachmentRepositoryInterface
+	}{
+		{
+			name: "Success Call Attachment Repository",
+			args: args{
+				db: db,
+			},
+			want: &AttachmentRepository{
+				DB: db,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAttachmentRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAttachmentRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentRepository_Create(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := context.Background()
+
+	type fields struct {
+		DB *sql.DB
+	}
+	type args struct {
+		ctx  context.Context
+		data types.Attachment
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantId  int64
+		wantErr bool
+		mock    func()
+	}{
+		{
+			name: "Success Create Attachment",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx:  ctx,
+				data: attachmentDataMock,
+			},
+			wantId:  int64(1),
+			wantErr: false,
+			mock: func() {
+				dbmock.ExpectPrepare(regexp.QuoteMeta(CreateAttachmentQuery)).
+					ExpectExec().
+					WithArgs(attachmentDataMock.TaskID, attachmentDataMock.Name, attachmentDataMock.Size, attachmentDataMock.ContentType, attachmentDataMock.SHA256, attachmentDataMock.StorageKey, attachmentDataMock.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+
+		t.Run(tt.name, func(t *testing.T) {
+			ar := &AttachmentRepository{
+				DB: tt.fields.DB,
+			}
+			gotId, err := ar.Create(tt.args.ctx, tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AttachmentRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotId != tt.wantId {
+				t.Errorf("AttachmentRepository.Create() = %v, want %v", gotId, tt.wantId)
+			}
+		})
+	}
+}
+
+func TestAttachmentRepository_GetByID(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	type fields struct {
+		DB *sql.DB
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantResult *types.Attachment
+		wantErr    bool
+		mock       func()
+	}{
+		{
+			name: "Success Get Attachment By ID",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx: ctx,
+				id:  int64(1),
+			},
+			wantResult: &attachmentDataMock,
+			wantErr:    false,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(GetAttachmentByID)).
+					WithArgs(principalMock.TenantID, attachmentDataMock.ID).
+					WillReturnRows(
+						dbmock.NewRows(attachmentColumns).
+							AddRow(attachmentDataMock.ID, attachmentDataMock.TaskID, attachmentDataMock.Name, attachmentDataMock.Size, attachmentDataMock.ContentType, attachmentDataMock.SHA256, attachmentDataMock.StorageKey, attachmentDataMock.CreatedAt),
+					)
+			},
+		},
+		{
+			name: "Attachment Not Found",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx: ctx,
+				id:  int64(2),
+			},
+			wantResult: nil,
+			wantErr:    true,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(GetAttachmentByID)).
+					WithArgs(principalMock.TenantID, int64(2)).
+					WillReturnRows(dbmock.NewRows(attachmentColumns))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+
+		t.Run(tt.name, func(t *testing.T) {
+			ar := &AttachmentRepository{
+				DB: tt.fields.DB,
+			}
+			gotResult, err := ar.GetByID(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AttachmentRepository.GetByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("AttachmentRepository.GetByID() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestAttachmentRepository_GetByTaskIDDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	type fields struct {
+		DB *sql.DB
+	}
+	type args struct {
+		ctx    context.Context
+		taskID int64
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantResult []types.Attachment
+		wantErr    bool
+		mock       func()
+	}{
+		{
+			name: "Success Retrive Task Attachments",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx:    ctx,
+				taskID: int64(1),
+			},
+			wantResult: []types.Attachment{
+				attachmentDataMock,
+			},
+			wantErr: false,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(GetAttachmentByTaskID)).
+					WithArgs(principalMock.TenantID, int64(1)).
+					WillReturnRows(
+						dbmock.NewRows(attachmentColumns).
+							AddRow(attachmentDataMock.ID, attachmentDataMock.TaskID, attachmentDataMock.Name, attachmentDataMock.Size, attachmentDataMock.ContentType, attachmentDataMock.SHA256, attachmentDataMock.StorageKey, attachmentDataMock.CreatedAt),
+					)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+
+		t.Run(tt.name, func(t *testing.T) {
+			ar := &AttachmentRepository{
+				DB: tt.fields.DB,
+			}
+			gotResult, err := ar.GetByTaskIDDB(tt.args.ctx, tt.args.taskID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AttachmentRepository.GetByTaskIDDB() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("AttachmentRepository.GetByTaskIDDB() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestAttachmentRepository_DeleteByIDDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	type fields struct {
+		DB *sql.DB
+	}
+	type args struct {
+		ctx context.Context
+		id  int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+		mock    func()
+	}{
+		{
+			name: "Success Delete Attachment",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx: ctx,
+				id:  1,
+			},
+			wantErr: false,
+			mock: func() {
+				dbmock.ExpectPrepare(regexp.QuoteMeta(DeleteAttachmentQuery)).
+					ExpectExec().
+					WithArgs(principalMock.TenantID, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+
+		t.Run(tt.name, func(t *testing.T) {
+			ar := &AttachmentRepository{
+				DB: tt.fields.DB,
+			}
+			if err := ar.DeleteByIDDB(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("AttachmentRepository.DeleteByIDDB() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
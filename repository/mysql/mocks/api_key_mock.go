@@ -0,0 +1,136 @@
+// Code generated by mockery v2.32.4. DO NOT EDIT.
+
+package todorepositorymock
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	types "github.com/winartodev/go-grpc/types"
+)
+
+// APIKeyRepositoryInterface is an autogenerated mock type for the APIKeyRepositoryInterface type
+type APIKeyRepositoryInterface struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, data
+func (_m *APIKeyRepositoryInterface) Create(ctx context.Context, data types.APIKey) (int64, error) {
+	ret := _m.Called(ctx, data)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.APIKey) (int64, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.APIKey) int64); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.APIKey) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByHashDB provides a mock function with given fields: ctx, hash
+func (_m *APIKeyRepositoryInterface) GetByHashDB(ctx context.Context, hash string) (*types.APIKey, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *types.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.APIKey, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.APIKey); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *APIKeyRepositoryInterface) GetByID(ctx context.Context, id int64) (*types.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *types.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*types.APIKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *types.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeByIDDB provides a mock function with given fields: ctx, id, revokedAt
+func (_m *APIKeyRepositoryInterface) RevokeByIDDB(ctx context.Context, id int64, revokedAt *time.Time) error {
+	ret := _m.Called(ctx, id, revokedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *time.Time) error); ok {
+		r0 = rf(ctx, id, revokedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLastUsedDB provides a mock function with given fields: ctx, id, usedAt, ip
+func (_m *APIKeyRepositoryInterface) UpdateLastUsedDB(ctx context.Context, id int64, usedAt *time.Time, ip string) error {
+	ret := _m.Called(ctx, id, usedAt, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *time.Time, string) error); ok {
+		r0 = rf(ctx, id, usedAt, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAPIKeyRepositoryInterface creates a new instance of APIKeyRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyRepositoryInterface {
+	mock := &APIKeyRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
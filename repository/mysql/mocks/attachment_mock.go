@@ -0,0 +1,120 @@
+// Code generated by mockery v2.32.4. DO NOT EDIT.
+
+package todorepositorymock
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/winartodev/go-grpc/types"
+)
+
+// AttachmentRepositoryInterface is an autogenerated mock type for the AttachmentRepositoryInterface type
+type AttachmentRepositoryInterface struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, data
+func (_m *AttachmentRepositoryInterface) Create(ctx context.Context, data types.Attachment) (int64, error) {
+	ret := _m.Called(ctx, data)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Attachment) (int64, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.Attachment) int64); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.Attachment) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByIDDB provides a mock function with given fields: ctx, id
+func (_m *AttachmentRepositoryInterface) DeleteByIDDB(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *AttachmentRepositoryInterface) GetByID(ctx context.Context, id int64) (*types.Attachment, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *types.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*types.Attachment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *types.Attachment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTaskIDDB provides a mock function with given fields: ctx, taskID
+func (_m *AttachmentRepositoryInterface) GetByTaskIDDB(ctx context.Context, taskID int64) ([]types.Attachment, error) {
+	ret := _m.Called(ctx, taskID)
+
+	var r0 []types.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]types.Attachment, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []types.Attachment); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAttachmentRepositoryInterface creates a new instance of AttachmentRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentRepositoryInterface {
+	mock := &AttachmentRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,10 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	search "github.com/winartodev/go-grpc/search"
+
+	time "time"
+
 	types "github.com/winartodev/go-grpc/types"
 )
 
@@ -53,25 +57,49 @@ func (_m *TodoRepositoryInterface) DeleteByIDDB(ctx context.Context, id int64) e
 	return r0
 }
 
-// GetAllTaskDB provides a mock function with given fields: ctx
-func (_m *TodoRepositoryInterface) GetAllTaskDB(ctx context.Context) ([]types.Task, error) {
-	ret := _m.Called(ctx)
+// GetAdjacentRankDB provides a mock function with given fields: ctx, rank, next, excludeID
+func (_m *TodoRepositoryInterface) GetAdjacentRankDB(ctx context.Context, rank string, next bool, excludeID int64) (string, error) {
+	ret := _m.Called(ctx, rank, next, excludeID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, int64) (string, error)); ok {
+		return rf(ctx, rank, next, excludeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, int64) string); ok {
+		r0 = rf(ctx, rank, next, excludeID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, int64) error); ok {
+		r1 = rf(ctx, rank, next, excludeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllTaskDB provides a mock function with given fields: ctx, filter
+func (_m *TodoRepositoryInterface) GetAllTaskDB(ctx context.Context, filter types.TaskFilter) ([]types.Task, error) {
+	ret := _m.Called(ctx, filter)
 
 	var r0 []types.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]types.Task, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, types.TaskFilter) ([]types.Task, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []types.Task); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, types.TaskFilter) []types.Task); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]types.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, types.TaskFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,6 +133,70 @@ func (_m *TodoRepositoryInterface) GetByID(ctx context.Context, id int64) (*type
 	return r0, r1
 }
 
+// GetLastRankDB provides a mock function with given fields: ctx
+func (_m *TodoRepositoryInterface) GetLastRankDB(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchTaskDB provides a mock function with given fields: ctx, query, limit
+func (_m *TodoRepositoryInterface) SearchTaskDB(ctx context.Context, query search.Query, limit int) ([]types.TaskSearchResult, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	var r0 []types.TaskSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, search.Query, int) ([]types.TaskSearchResult, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, search.Query, int) []types.TaskSearchResult); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.TaskSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, search.Query, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAssigneeByIDDB provides a mock function with given fields: ctx, id, assigneeID, updatedAt
+func (_m *TodoRepositoryInterface) UpdateAssigneeByIDDB(ctx context.Context, id int64, assigneeID string, updatedAt *time.Time) error {
+	ret := _m.Called(ctx, id, assigneeID, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *time.Time) error); ok {
+		r0 = rf(ctx, id, assigneeID, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateByIDDB provides a mock function with given fields: ctx, id, data
 func (_m *TodoRepositoryInterface) UpdateByIDDB(ctx context.Context, id int64, data types.Task) error {
 	ret := _m.Called(ctx, id, data)
@@ -119,6 +211,20 @@ func (_m *TodoRepositoryInterface) UpdateByIDDB(ctx context.Context, id int64, d
 	return r0
 }
 
+// UpdateRankByIDDB provides a mock function with given fields: ctx, id, rank, updatedAt
+func (_m *TodoRepositoryInterface) UpdateRankByIDDB(ctx context.Context, id int64, rank string, updatedAt *time.Time) error {
+	ret := _m.Called(ctx, id, rank, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *time.Time) error); ok {
+		r0 = rf(ctx, id, rank, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTodoRepositoryInterface creates a new instance of TodoRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTodoRepositoryInterface(t interface {
@@ -3,10 +3,25 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"strings"
+	"time"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/search"
 	"github.com/winartodev/go-grpc/types"
 )
 
+// ErrDuplicateRank is returned when another task of the tenant already has the rank, which
+// happens when two tasks are created or moved to the same place concurrently.
+var ErrDuplicateRank = errors.New("task rank is already taken")
+
+// mysqlErrDuplicateEntry is ER_DUP_ENTRY.
+const mysqlErrDuplicateEntry = 1062
+
+// TodoRepository scopes every query to the tenant of the caller in ctx, so a task of another
+// tenant behaves as if it did not exist.
 type TodoRepository struct {
 	DB *sql.DB
 }
@@ -14,8 +29,13 @@ type TodoRepository struct {
 type TodoRepositoryInterface interface {
 	Create(ctx context.Context, data types.Task) (id int64, err error)
 	GetByID(ctx context.Context, id int64) (result *types.Task, err error)
-	GetAllTaskDB(ctx context.Context) (result []types.Task, err error)
+	GetAllTaskDB(ctx context.Context, filter types.TaskFilter) (result []types.Task, err error)
+	SearchTaskDB(ctx context.Context, query search.Query, limit int) (result []types.TaskSearchResult, err error)
+	GetLastRankDB(ctx context.Context) (rank string, err error)
+	GetAdjacentRankDB(ctx context.Context, rank string, next bool, excludeID int64) (result string, err error)
 	UpdateByIDDB(ctx context.Context, id int64, data types.Task) (err error)
+	UpdateRankByIDDB(ctx context.Context, id int64, rank string, updatedAt *time.Time) (err error)
+	UpdateAssigneeByIDDB(ctx context.Context, id int64, assigneeID string, updatedAt *time.Time) (err error)
 	DeleteByIDDB(ctx context.Context, id int64) (err error)
 }
 
@@ -26,27 +46,28 @@ func NewTodoRepository(db *sql.DB) TodoRepositoryInterface {
 }
 
 func (tr *TodoRepository) Create(ctx context.Context, data types.Task) (id int64, err error) {
-	stmt, err := tr.DB.Prepare(CreateTaskQuery)
+	stmt, err := tr.DB.PrepareContext(ctx, CreateTaskQuery)
 	if err != nil {
 		return id, err
 	}
+	defer stmt.Close()
 
-	res, err := stmt.Exec(data.Description, data.Completed, data.CreatedAt)
+	res, err := stmt.ExecContext(ctx, auth.TenantFromContext(ctx), data.Description, data.Status, data.Completed, data.Rank, data.AssigneeID, data.CreatorID, data.CreatedAt)
 	if err != nil {
-		return id, err
+		return id, rankError(err)
 	}
 
 	return res.LastInsertId()
 }
 
 func (tr *TodoRepository) GetByID(ctx context.Context, id int64) (result *types.Task, err error) {
-	row := tr.DB.QueryRow(GetTaskByID, id)
+	row := tr.DB.QueryRowContext(ctx, GetTaskByID, auth.TenantFromContext(ctx), id)
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
 
 	var task types.Task
-	err = row.Scan(&task.ID, &task.Description, &task.Completed, &task.CreatedAt, &task.UpdatedAt)
+	err = row.Scan(&task.ID, &task.Description, &task.Status, &task.Completed, &task.Rank, &task.AssigneeID, &task.CreatorID, &task.CreatedAt, &task.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -54,16 +75,17 @@ func (tr *TodoRepository) GetByID(ctx context.Context, id int64) (result *types.
 	return &task, nil
 }
 
-func (tr *TodoRepository) GetAllTaskDB(ctx context.Context) (result []types.Task, err error) {
-	rows, err := tr.DB.Query(GetAllTask)
+func (tr *TodoRepository) GetAllTaskDB(ctx context.Context, filter types.TaskFilter) (result []types.Task, err error) {
+	rows, err := tr.DB.QueryContext(ctx, GetAllTask, auth.TenantFromContext(ctx), filter.AssigneeID, filter.AssigneeID, filter.CreatorID, filter.CreatorID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	var tasks []types.Task
 	for rows.Next() {
 		var task types.Task
-		err := rows.Scan(&task.ID, &task.Description, &task.Completed, &task.CreatedAt, &task.UpdatedAt)
+		err := rows.Scan(&task.ID, &task.Description, &task.Status, &task.Completed, &task.Rank, &task.AssigneeID, &task.CreatorID, &task.CreatedAt, &task.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -78,13 +100,121 @@ func (tr *TodoRepository) GetAllTaskDB(ctx context.Context) (result []types.Task
 	return tasks, err
 }
 
+func (tr *TodoRepository) SearchTaskDB(ctx context.Context, query search.Query, limit int) (result []types.TaskSearchResult, err error) {
+	against := booleanModeQuery(query)
+
+	rows, err := tr.DB.QueryContext(ctx, SearchTaskQuery, against, auth.TenantFromContext(ctx), against, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []types.TaskSearchResult
+	for rows.Next() {
+		var res types.TaskSearchResult
+		err := rows.Scan(&res.Task.ID, &res.Task.Description, &res.Task.Status, &res.Task.Completed, &res.Task.Rank, &res.Task.AssigneeID, &res.Task.CreatorID, &res.Task.CreatedAt, &res.Task.UpdatedAt, &res.Score)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, res)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// booleanModeQuery renders the query for MATCH ... AGAINST in boolean mode, where every
+// term and phrase is required. Tokens only contain letters and digits, so no operator can leak in.
+func booleanModeQuery(query search.Query) string {
+	var parts []string
+	for _, term := range query.Terms {
+		parts = append(parts, "+"+term)
+	}
+
+	for _, phrase := range query.Phrases {
+		parts = append(parts, `+"`+strings.Join(phrase, " ")+`"`)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (tr *TodoRepository) GetLastRankDB(ctx context.Context) (rank string, err error) {
+	err = tr.DB.QueryRowContext(ctx, GetLastRankQuery, auth.TenantFromContext(ctx)).Scan(&rank)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return rank, err
+}
+
+// GetAdjacentRankDB returns the rank sorting right after (next) or right before the given rank,
+// ignoring the task excludeID. An empty rank is returned when there is no such task.
+func (tr *TodoRepository) GetAdjacentRankDB(ctx context.Context, rank string, next bool, excludeID int64) (result string, err error) {
+	query := GetPreviousRankQuery
+	if next {
+		query = GetNextRankQuery
+	}
+
+	err = tr.DB.QueryRowContext(ctx, query, auth.TenantFromContext(ctx), rank, excludeID).Scan(&result)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return result, err
+}
+
 func (tr *TodoRepository) UpdateByIDDB(ctx context.Context, id int64, data types.Task) (err error) {
-	stmt, err := tr.DB.Prepare(UpdateTaskQuery)
+	stmt, err := tr.DB.PrepareContext(ctx, UpdateTaskQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, data.Description, data.Status, data.Completed, data.UpdatedAt, auth.TenantFromContext(ctx), id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (tr *TodoRepository) UpdateRankByIDDB(ctx context.Context, id int64, rank string, updatedAt *time.Time) (err error) {
+	stmt, err := tr.DB.PrepareContext(ctx, UpdateTaskRankQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, rank, updatedAt, auth.TenantFromContext(ctx), id)
+	if err != nil {
+		return rankError(err)
+	}
+
+	return nil
+}
+
+// rankError maps a violation of the unique (tenant_id, sort_rank) index to ErrDuplicateRank.
+func rankError(err error) error {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return ErrDuplicateRank
+	}
+
+	return err
+}
+
+func (tr *TodoRepository) UpdateAssigneeByIDDB(ctx context.Context, id int64, assigneeID string, updatedAt *time.Time) (err error) {
+	stmt, err := tr.DB.PrepareContext(ctx, UpdateTaskAssigneeQuery)
 	if err != nil {
 		return err
 	}
+	defer stmt.Close()
 
-	_, err = stmt.Exec(data.Description, data.Completed, data.UpdatedAt, id)
+	_, err = stmt.ExecContext(ctx, assigneeID, updatedAt, auth.TenantFromContext(ctx), id)
 	if err != nil {
 		return err
 	}
@@ -93,12 +223,13 @@ func (tr *TodoRepository) UpdateByIDDB(ctx context.Context, id int64, data types
 }
 
 func (tr *TodoRepository) DeleteByIDDB(ctx context.Context, id int64) (err error) {
-	stmt, err := tr.DB.Prepare(DeleteTaskQuery)
+	stmt, err := tr.DB.PrepareContext(ctx, DeleteTaskQuery)
 	if err != nil {
 		return err
 	}
+	defer stmt.Close()
 
-	_, err = stmt.Exec(id)
+	_, err = stmt.ExecContext(ctx, auth.TenantFromContext(ctx), id)
 	if err != nil {
 		return err
 	}
@@ -1,13 +1,25 @@
 package mysql
 
 var (
-	CreateTaskQuery = `INSERT INTO task (id, description, complete, created_at, updated_at) VALUES (NULL, ?, ?, ?, NULL);`
+	CreateTaskQuery = `INSERT INTO task (id, tenant_id, description, status, complete, sort_rank, assignee_id, creator_id, created_at, updated_at) VALUES (NULL, ?, ?, ?, ?, ?, ?, ?, ?, NULL);`
 
-	GetTaskByID = `SELECT id, description, complete, created_at, updated_at FROM task WHERE id = ?;`
+	GetTaskByID = `SELECT id, description, status, complete, sort_rank, assignee_id, creator_id, created_at, updated_at FROM task WHERE tenant_id = ? AND id = ?;`
 
-	GetAllTask = `SELECT id, description, complete, created_at, updated_at FROM task;`
+	GetAllTask = `SELECT id, description, status, complete, sort_rank, assignee_id, creator_id, created_at, updated_at FROM task WHERE tenant_id = ? AND (? = '' OR assignee_id = ?) AND (? = '' OR creator_id = ?) ORDER BY sort_rank ASC, id ASC;`
 
-	UpdateTaskQuery = `UPDATE task SET description = ?, complete = ?, updated_at = ? WHERE id = ?;`
+	SearchTaskQuery = `SELECT id, description, status, complete, sort_rank, assignee_id, creator_id, created_at, updated_at, MATCH (description) AGAINST (? IN BOOLEAN MODE) AS score FROM task WHERE tenant_id = ? AND MATCH (description) AGAINST (? IN BOOLEAN MODE) ORDER BY score DESC, id ASC LIMIT ?;`
 
-	DeleteTaskQuery = `DELETE FROM task WHERE id = ?;`
+	GetLastRankQuery = `SELECT sort_rank FROM task WHERE tenant_id = ? ORDER BY sort_rank DESC LIMIT 1;`
+
+	GetNextRankQuery = `SELECT sort_rank FROM task WHERE tenant_id = ? AND sort_rank > ? AND id <> ? ORDER BY sort_rank ASC LIMIT 1;`
+
+	GetPreviousRankQuery = `SELECT sort_rank FROM task WHERE tenant_id = ? AND sort_rank < ? AND id <> ? ORDER BY sort_rank DESC LIMIT 1;`
+
+	UpdateTaskQuery = `UPDATE task SET description = ?, status = ?, complete = ?, updated_at = ? WHERE tenant_id = ? AND id = ?;`
+
+	UpdateTaskAssigneeQuery = `UPDATE task SET assignee_id = ?, updated_at = ? WHERE tenant_id = ? AND id = ?;`
+
+	UpdateTaskRankQuery = `UPDATE task SET sort_rank = ?, updated_at = ? WHERE tenant_id = ? AND id = ?;`
+
+	DeleteTaskQuery = `DELETE FROM task WHERE tenant_id = ? AND id = ?;`
 )
@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log"
 	"reflect"
 	"regexp"
@@ -11,6 +12,9 @@ import (
 
 	"bou.ke/monkey"
 	"github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/search"
 	"github.com/winartodev/go-grpc/types"
 )
 
@@ -20,10 +24,24 @@ var (
 	dataMock = types.Task{
 		ID:          1,
 		Description: "Test",
+		Status:      "todo",
 		Completed:   false,
+		Rank:        "V",
+		AssigneeID:  "bob",
+		CreatorID:   "alice",
 		CreatedAt:   &mockTime,
 		UpdatedAt:   &mockTime,
 	}
+
+	principalMock = auth.Principal{
+		UserID:   "alice",
+		TenantID: "acme",
+	}
+
+	otherTenantMock = auth.Principal{
+		UserID:   "mallory",
+		TenantID: "globex",
+	}
 )
 
 func NewMock() (*sql.DB, sqlmock.Sqlmock) {
@@ -67,7 +85,7 @@ func TestNewTodoRepository(t *testing.T) {
 
 func TestTodoRepository_Create(t *testing.T) {
 	db, dbmock := NewMock()
-	ctx := context.Background()
+	ctx := auth.NewContext(context.Background(), principalMock)
 
 	monkey.Patch(time.Now, func() time.Time {
 		return mockTime
@@ -103,10 +121,28 @@ func TestTodoRepository_Create(t *testing.T) {
 			mock: func() {
 				dbmock.ExpectPrepare(regexp.QuoteMeta(CreateTaskQuery)).
 					ExpectExec().
-					WithArgs(dataMock.Description, dataMock.Completed, dataMock.CreatedAt).
+					WithArgs(principalMock.TenantID, dataMock.Description, dataMock.Status, dataMock.Completed, dataMock.Rank, dataMock.AssigneeID, dataMock.CreatorID, dataMock.CreatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
+		{
+			name: "Duplicate Rank",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx:  ctx,
+				data: dataMock,
+			},
+			wantId:  int64(0),
+			wantErr: true,
+			mock: func() {
+				dbmock.ExpectPrepare(regexp.QuoteMeta(CreateTaskQuery)).
+					ExpectExec().
+					WithArgs(principalMock.TenantID, dataMock.Description, dataMock.Status, dataMock.Completed, dataMock.Rank, dataMock.AssigneeID, dataMock.CreatorID, dataMock.CreatedAt).
+					WillReturnError(&mysqldriver.MySQLError{Number: mysqlErrDuplicateEntry, Message: "Duplicate entry 'acme-V'"})
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt.mock()
@@ -130,7 +166,7 @@ func TestTodoRepository_Create(t *testing.T) {
 
 func TestTodoRepository_GetByID(t *testing.T) {
 	db, dbmock := NewMock()
-	ctx := context.Background()
+	ctx := auth.NewContext(context.Background(), principalMock)
 
 	monkey.Patch(time.Now, func() time.Time {
 		return mockTime
@@ -165,10 +201,10 @@ func TestTodoRepository_GetByID(t *testing.T) {
 			wantErr:    false,
 			mock: func() {
 				dbmock.ExpectQuery(regexp.QuoteMeta(GetTaskByID)).
-					WithArgs(dataMock.ID).
+					WithArgs(principalMock.TenantID, dataMock.ID).
 					WillReturnRows(
-						dbmock.NewRows([]string{"id", "description", "complete", "created_at", "updated_at"}).
-							AddRow(dataMock.ID, dataMock.Description, dataMock.Completed, dataMock.CreatedAt, dataMock.UpdatedAt),
+						dbmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at"}).
+							AddRow(dataMock.ID, dataMock.Description, dataMock.Status, dataMock.Completed, dataMock.Rank, dataMock.AssigneeID, dataMock.CreatorID, dataMock.CreatedAt, dataMock.UpdatedAt),
 					)
 			},
 		},
@@ -194,7 +230,7 @@ func TestTodoRepository_GetByID(t *testing.T) {
 
 func TestTodoRepository_GetAllTaskDB(t *testing.T) {
 	db, dbmock := NewMock()
-	ctx := context.Background()
+	ctx := auth.NewContext(context.Background(), principalMock)
 
 	monkey.Patch(time.Now, func() time.Time {
 		return mockTime
@@ -205,7 +241,8 @@ func TestTodoRepository_GetAllTaskDB(t *testing.T) {
 		DB *sql.DB
 	}
 	type args struct {
-		ctx context.Context
+		ctx    context.Context
+		filter types.TaskFilter
 	}
 	tests := []struct {
 		name       string
@@ -222,6 +259,9 @@ func TestTodoRepository_GetAllTaskDB(t *testing.T) {
 			},
 			args: args{
 				ctx: ctx,
+				filter: types.TaskFilter{
+					AssigneeID: "bob",
+				},
 			},
 			wantResult: []types.Task{
 				dataMock,
@@ -229,9 +269,10 @@ func TestTodoRepository_GetAllTaskDB(t *testing.T) {
 			wantErr: false,
 			mock: func() {
 				dbmock.ExpectQuery(regexp.QuoteMeta(GetAllTask)).
+					WithArgs(principalMock.TenantID, "bob", "bob", "", "").
 					WillReturnRows(
-						dbmock.NewRows([]string{"id", "description", "complete", "created_at", "updated_at"}).
-							AddRow(dataMock.ID, dataMock.Description, dataMock.Completed, dataMock.CreatedAt, dataMock.UpdatedAt),
+						dbmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at"}).
+							AddRow(dataMock.ID, dataMock.Description, dataMock.Status, dataMock.Completed, dataMock.Rank, dataMock.AssigneeID, dataMock.CreatorID, dataMock.CreatedAt, dataMock.UpdatedAt),
 					)
 			},
 		},
@@ -243,7 +284,7 @@ func TestTodoRepository_GetAllTaskDB(t *testing.T) {
 			tr := &TodoRepository{
 				DB: tt.fields.DB,
 			}
-			gotResult, err := tr.GetAllTaskDB(tt.args.ctx)
+			gotResult, err := tr.GetAllTaskDB(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("TodoRepository.GetAllTaskDB() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -255,9 +296,246 @@ func TestTodoRepository_GetAllTaskDB(t *testing.T) {
 	}
 }
 
+func TestTodoRepository_GetAllTaskDB_ClosesRowsOnScanError(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(GetAllTask)).
+		WithArgs(principalMock.TenantID, "", "", "", "").
+		WillReturnRows(
+			dbmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at"}).
+				AddRow("not a number", dataMock.Description, dataMock.Status, dataMock.Completed, dataMock.Rank, dataMock.AssigneeID, dataMock.CreatorID, dataMock.CreatedAt, dataMock.UpdatedAt),
+		).
+		RowsWillBeClosed()
+
+	tr := &TodoRepository{
+		DB: db,
+	}
+	if _, err := tr.GetAllTaskDB(ctx, types.TaskFilter{}); err == nil {
+		t.Fatal("TodoRepository.GetAllTaskDB() error = nil, want the scan error")
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTodoRepository_SearchTaskDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	type fields struct {
+		DB *sql.DB
+	}
+	type args struct {
+		ctx   context.Context
+		query search.Query
+		limit int
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantResult []types.TaskSearchResult
+		wantErr    bool
+		mock       func()
+	}{
+		{
+			name: "Success Search Task",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx:   ctx,
+				query: search.ParseQuery(`test "fresh milk"`),
+				limit: 20,
+			},
+			wantResult: []types.TaskSearchResult{
+				{
+					Task:  dataMock,
+					Score: 1.5,
+				},
+			},
+			wantErr: false,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(SearchTaskQuery)).
+					WithArgs(`+test +"fresh milk"`, principalMock.TenantID, `+test +"fresh milk"`, 20).
+					WillReturnRows(
+						dbmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at", "score"}).
+							AddRow(dataMock.ID, dataMock.Description, dataMock.Status, dataMock.Completed, dataMock.Rank, dataMock.AssigneeID, dataMock.CreatorID, dataMock.CreatedAt, dataMock.UpdatedAt, 1.5),
+					)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		defer tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoRepository{
+				DB: tt.fields.DB,
+			}
+			gotResult, err := tr.SearchTaskDB(tt.args.ctx, tt.args.query, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoRepository.SearchTaskDB() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("TodoRepository.SearchTaskDB() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestTodoRepository_GetLastRankDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	type fields struct {
+		DB *sql.DB
+	}
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantRank string
+		wantErr  bool
+		mock     func()
+	}{
+		{
+			name: "Success Get Last Rank",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx: ctx,
+			},
+			wantRank: "V",
+			wantErr:  false,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(GetLastRankQuery)).
+					WithArgs(principalMock.TenantID).
+					WillReturnRows(dbmock.NewRows([]string{"sort_rank"}).AddRow("V"))
+			},
+		},
+		{
+			name: "Empty List",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx: ctx,
+			},
+			wantRank: "",
+			wantErr:  false,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(GetLastRankQuery)).
+					WithArgs(principalMock.TenantID).
+					WillReturnRows(dbmock.NewRows([]string{"sort_rank"}))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoRepository{
+				DB: tt.fields.DB,
+			}
+			gotRank, err := tr.GetLastRankDB(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoRepository.GetLastRankDB() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotRank != tt.wantRank {
+				t.Errorf("TodoRepository.GetLastRankDB() = %v, want %v", gotRank, tt.wantRank)
+			}
+		})
+	}
+}
+
+func TestTodoRepository_GetAdjacentRankDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	type fields struct {
+		DB *sql.DB
+	}
+	type args struct {
+		ctx       context.Context
+		rank      string
+		next      bool
+		excludeID int64
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantResult string
+		wantErr    bool
+		mock       func()
+	}{
+		{
+			name: "Success Get Next Rank",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx:       ctx,
+				rank:      "V",
+				next:      true,
+				excludeID: 1,
+			},
+			wantResult: "k",
+			wantErr:    false,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(GetNextRankQuery)).
+					WithArgs(principalMock.TenantID, "V", int64(1)).
+					WillReturnRows(dbmock.NewRows([]string{"sort_rank"}).AddRow("k"))
+			},
+		},
+		{
+			name: "No Previous Rank",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx:       ctx,
+				rank:      "V",
+				next:      false,
+				excludeID: 1,
+			},
+			wantResult: "",
+			wantErr:    false,
+			mock: func() {
+				dbmock.ExpectQuery(regexp.QuoteMeta(GetPreviousRankQuery)).
+					WithArgs(principalMock.TenantID, "V", int64(1)).
+					WillReturnRows(dbmock.NewRows([]string{"sort_rank"}))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoRepository{
+				DB: tt.fields.DB,
+			}
+			gotResult, err := tr.GetAdjacentRankDB(tt.args.ctx, tt.args.rank, tt.args.next, tt.args.excludeID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoRepository.GetAdjacentRankDB() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotResult != tt.wantResult {
+				t.Errorf("TodoRepository.GetAdjacentRankDB() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
 func TestTodoRepository_UpdateByIDDB(t *testing.T) {
 	db, dbmock := NewMock()
-	ctx := context.Background()
+	ctx := auth.NewContext(context.Background(), principalMock)
 
 	monkey.Patch(time.Now, func() time.Time {
 		return mockTime
@@ -293,7 +571,7 @@ func TestTodoRepository_UpdateByIDDB(t *testing.T) {
 			mock: func() {
 				dbmock.ExpectPrepare(regexp.QuoteMeta(UpdateTaskQuery)).
 					ExpectExec().
-					WithArgs(dataMock.Description, dataMock.Completed, dataMock.UpdatedAt, int64(1)).
+					WithArgs(dataMock.Description, dataMock.Status, dataMock.Completed, dataMock.UpdatedAt, principalMock.TenantID, int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 		},
@@ -312,9 +590,115 @@ func TestTodoRepository_UpdateByIDDB(t *testing.T) {
 	}
 }
 
+func TestTodoRepository_UpdateRankByIDDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	type fields struct {
+		DB *sql.DB
+	}
+	type args struct {
+		ctx       context.Context
+		id        int64
+		rank      string
+		updatedAt *time.Time
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+		mock    func()
+	}{
+		{
+			name: "Success Update Task Rank",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx:       ctx,
+				id:        1,
+				rank:      "aV",
+				updatedAt: &mockTime,
+			},
+			wantErr: false,
+			mock: func() {
+				dbmock.ExpectPrepare(regexp.QuoteMeta(UpdateTaskRankQuery)).
+					ExpectExec().
+					WithArgs("aV", &mockTime, principalMock.TenantID, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoRepository{
+				DB: tt.fields.DB,
+			}
+			if err := tr.UpdateRankByIDDB(tt.args.ctx, tt.args.id, tt.args.rank, tt.args.updatedAt); (err != nil) != tt.wantErr {
+				t.Errorf("TodoRepository.UpdateRankByIDDB() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTodoRepository_UpdateAssigneeByIDDB(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), principalMock)
+
+	type fields struct {
+		DB *sql.DB
+	}
+	type args struct {
+		ctx        context.Context
+		id         int64
+		assigneeID string
+		updatedAt  *time.Time
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+		mock    func()
+	}{
+		{
+			name: "Success Update Task Assignee",
+			fields: fields{
+				DB: db,
+			},
+			args: args{
+				ctx:        ctx,
+				id:         1,
+				assigneeID: "bob",
+				updatedAt:  &mockTime,
+			},
+			wantErr: false,
+			mock: func() {
+				dbmock.ExpectPrepare(regexp.QuoteMeta(UpdateTaskAssigneeQuery)).
+					ExpectExec().
+					WithArgs("bob", &mockTime, principalMock.TenantID, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoRepository{
+				DB: tt.fields.DB,
+			}
+			if err := tr.UpdateAssigneeByIDDB(tt.args.ctx, tt.args.id, tt.args.assigneeID, tt.args.updatedAt); (err != nil) != tt.wantErr {
+				t.Errorf("TodoRepository.UpdateAssigneeByIDDB() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestTodoRepository_DeleteByIDDB(t *testing.T) {
 	db, dbmock := NewMock()
-	ctx := context.Background()
+	ctx := auth.NewContext(context.Background(), principalMock)
 
 	monkey.Patch(time.Now, func() time.Time {
 		return mockTime
@@ -348,7 +732,7 @@ func TestTodoRepository_DeleteByIDDB(t *testing.T) {
 			mock: func() {
 				dbmock.ExpectPrepare(regexp.QuoteMeta(DeleteTaskQuery)).
 					ExpectExec().
-					WithArgs(int64(1)).
+					WithArgs(principalMock.TenantID, int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 		},
@@ -366,3 +750,77 @@ func TestTodoRepository_DeleteByIDDB(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoRepository_TenantIsolation(t *testing.T) {
+	db, dbmock := NewMock()
+	ctx := auth.NewContext(context.Background(), otherTenantMock)
+	tr := &TodoRepository{
+		DB: db,
+	}
+
+	// task 1 belongs to principalMock's tenant, so every statement from another tenant
+	// must carry that tenant and match nothing
+	dbmock.ExpectQuery(regexp.QuoteMeta(GetTaskByID)).
+		WithArgs(otherTenantMock.TenantID, dataMock.ID).
+		WillReturnRows(dbmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at"}))
+	dbmock.ExpectPrepare(regexp.QuoteMeta(UpdateTaskQuery)).
+		ExpectExec().
+		WithArgs(dataMock.Description, dataMock.Status, dataMock.Completed, dataMock.UpdatedAt, otherTenantMock.TenantID, dataMock.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbmock.ExpectPrepare(regexp.QuoteMeta(UpdateTaskAssigneeQuery)).
+		ExpectExec().
+		WithArgs("mallory", &mockTime, otherTenantMock.TenantID, dataMock.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbmock.ExpectPrepare(regexp.QuoteMeta(DeleteTaskQuery)).
+		ExpectExec().
+		WithArgs(otherTenantMock.TenantID, dataMock.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if _, err := tr.GetByID(ctx, dataMock.ID); err != sql.ErrNoRows {
+		t.Errorf("TodoRepository.GetByID() error = %v, want %v", err, sql.ErrNoRows)
+	}
+	if err := tr.UpdateByIDDB(ctx, dataMock.ID, dataMock); err != nil {
+		t.Errorf("TodoRepository.UpdateByIDDB() error = %v", err)
+	}
+	if err := tr.UpdateAssigneeByIDDB(ctx, dataMock.ID, "mallory", &mockTime); err != nil {
+		t.Errorf("TodoRepository.UpdateAssigneeByIDDB() error = %v", err)
+	}
+	if err := tr.DeleteByIDDB(ctx, dataMock.ID); err != nil {
+		t.Errorf("TodoRepository.DeleteByIDDB() error = %v", err)
+	}
+
+	if err := dbmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRankError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "Duplicate Entry",
+			err:  &mysqldriver.MySQLError{Number: mysqlErrDuplicateEntry},
+			want: ErrDuplicateRank,
+		},
+		{
+			name: "Other MySQL Error",
+			err:  &mysqldriver.MySQLError{Number: 1213},
+			want: &mysqldriver.MySQLError{Number: 1213},
+		},
+		{
+			name: "Other Error",
+			err:  sql.ErrConnDone,
+			want: sql.ErrConnDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rankError(tt.err); !errors.Is(got, tt.want) && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
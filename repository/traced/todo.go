@@ -0,0 +1,99 @@
+// Package traced wraps the todo repository so every query runs in its own span.
+package traced
+
+import (
+	"context"
+	"time"
+
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	"github.com/winartodev/go-grpc/search"
+	"github.com/winartodev/go-grpc/tracing"
+	"github.com/winartodev/go-grpc/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TodoRepository struct {
+	Repository todoRepository.TodoRepositoryInterface
+}
+
+func NewTodoRepository(repository todoRepository.TodoRepositoryInterface) todoRepository.TodoRepositoryInterface {
+	return &TodoRepository{
+		Repository: repository,
+	}
+}
+
+func (tr *TodoRepository) start(ctx context.Context, method string, query string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "TodoRepository."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "mysql"),
+			attribute.String("db.statement", query),
+		),
+	)
+}
+
+func (tr *TodoRepository) Create(ctx context.Context, data types.Task) (id int64, err error) {
+	ctx, span := tr.start(ctx, "Create", todoRepository.CreateTaskQuery)
+	defer tracing.End(span, &err)
+	return tr.Repository.Create(ctx, data)
+}
+
+func (tr *TodoRepository) GetByID(ctx context.Context, id int64) (result *types.Task, err error) {
+	ctx, span := tr.start(ctx, "GetByID", todoRepository.GetTaskByID)
+	defer tracing.End(span, &err)
+	return tr.Repository.GetByID(ctx, id)
+}
+
+func (tr *TodoRepository) GetAllTaskDB(ctx context.Context, filter types.TaskFilter) (result []types.Task, err error) {
+	ctx, span := tr.start(ctx, "GetAllTaskDB", todoRepository.GetAllTask)
+	defer tracing.End(span, &err)
+	return tr.Repository.GetAllTaskDB(ctx, filter)
+}
+
+func (tr *TodoRepository) SearchTaskDB(ctx context.Context, query search.Query, limit int) (result []types.TaskSearchResult, err error) {
+	ctx, span := tr.start(ctx, "SearchTaskDB", todoRepository.SearchTaskQuery)
+	defer tracing.End(span, &err)
+	return tr.Repository.SearchTaskDB(ctx, query, limit)
+}
+
+func (tr *TodoRepository) GetLastRankDB(ctx context.Context) (rank string, err error) {
+	ctx, span := tr.start(ctx, "GetLastRankDB", todoRepository.GetLastRankQuery)
+	defer tracing.End(span, &err)
+	return tr.Repository.GetLastRankDB(ctx)
+}
+
+func (tr *TodoRepository) GetAdjacentRankDB(ctx context.Context, rank string, next bool, excludeID int64) (result string, err error) {
+	query := todoRepository.GetPreviousRankQuery
+	if next {
+		query = todoRepository.GetNextRankQuery
+	}
+
+	ctx, span := tr.start(ctx, "GetAdjacentRankDB", query)
+	defer tracing.End(span, &err)
+	return tr.Repository.GetAdjacentRankDB(ctx, rank, next, excludeID)
+}
+
+func (tr *TodoRepository) UpdateByIDDB(ctx context.Context, id int64, data types.Task) (err error) {
+	ctx, span := tr.start(ctx, "UpdateByIDDB", todoRepository.UpdateTaskQuery)
+	defer tracing.End(span, &err)
+	return tr.Repository.UpdateByIDDB(ctx, id, data)
+}
+
+func (tr *TodoRepository) UpdateRankByIDDB(ctx context.Context, id int64, rank string, updatedAt *time.Time) (err error) {
+	ctx, span := tr.start(ctx, "UpdateRankByIDDB", todoRepository.UpdateTaskRankQuery)
+	defer tracing.End(span, &err)
+	return tr.Repository.UpdateRankByIDDB(ctx, id, rank, updatedAt)
+}
+
+func (tr *TodoRepository) UpdateAssigneeByIDDB(ctx context.Context, id int64, assigneeID string, updatedAt *time.Time) (err error) {
+	ctx, span := tr.start(ctx, "UpdateAssigneeByIDDB", todoRepository.UpdateTaskAssigneeQuery)
+	defer tracing.End(span, &err)
+	return tr.Repository.UpdateAssigneeByIDDB(ctx, id, assigneeID, updatedAt)
+}
+
+func (tr *TodoRepository) DeleteByIDDB(ctx context.Context, id int64) (err error) {
+	ctx, span := tr.start(ctx, "DeleteByIDDB", todoRepository.DeleteTaskQuery)
+	defer tracing.End(span, &err)
+	return tr.Repository.DeleteByIDDB(ctx, id)
+}
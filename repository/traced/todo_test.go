@@ -0,0 +1,45 @@
+package traced
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	todoRepositoryMock "github.com/winartodev/go-grpc/repository/mysql/mocks"
+	"github.com/winartodev/go-grpc/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTodoRepository_TracesQueries(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx := context.Background()
+	repository := new(todoRepositoryMock.TodoRepositoryInterface)
+	repository.On("GetByID", mock.Anything, int64(1)).Return(&types.Task{ID: 1}, nil)
+	repository.On("DeleteByIDDB", mock.Anything, int64(2)).Return(sql.ErrConnDone)
+
+	tr := NewTodoRepository(repository)
+
+	if task, err := tr.GetByID(ctx, 1); err != nil || task.ID != 1 {
+		t.Fatalf("TodoRepository.GetByID() = %v, %v", task, err)
+	}
+	if err := tr.DeleteByIDDB(ctx, 2); err != sql.ErrConnDone {
+		t.Fatalf("TodoRepository.DeleteByIDDB() error = %v, want %v", err, sql.ErrConnDone)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended spans = %d, want 2", len(spans))
+	}
+	if spans[0].Name() != "TodoRepository.GetByID" || spans[0].Status().Code != codes.Unset {
+		t.Errorf("span 0 = %s (%v), want TodoRepository.GetByID (unset)", spans[0].Name(), spans[0].Status().Code)
+	}
+	if spans[1].Name() != "TodoRepository.DeleteByIDDB" || spans[1].Status().Code != codes.Error {
+		t.Errorf("span 1 = %s (%v), want TodoRepository.DeleteByIDDB (error)", spans[1].Name(), spans[1].Status().Code)
+	}
+}
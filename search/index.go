@@ -0,0 +1,185 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/winartodev/go-grpc/types"
+)
+
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Index is an in-process inverted index over task descriptions for backends without
+// full-text support. It keeps term positions so that phrases can be matched.
+type Index struct {
+	mu       sync.RWMutex
+	tasks    map[int64]types.Task
+	lengths  map[int64]int
+	postings map[string]map[int64][]int
+	totalLen int
+}
+
+func NewIndex() *Index {
+	return &Index{
+		tasks:    make(map[int64]types.Task),
+		lengths:  make(map[int64]int),
+		postings: make(map[string]map[int64][]int),
+	}
+}
+
+// Add indexes the task, replacing any previously indexed version with the same ID.
+func (idx *Index) Add(task types.Task) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(task.ID)
+
+	tokens := Tokenize(task.Description)
+	for pos, token := range tokens {
+		docs, ok := idx.postings[token]
+		if !ok {
+			docs = make(map[int64][]int)
+			idx.postings[token] = docs
+		}
+
+		docs[task.ID] = append(docs[task.ID], pos)
+	}
+
+	idx.tasks[task.ID] = task
+	idx.lengths[task.ID] = len(tokens)
+	idx.totalLen += len(tokens)
+}
+
+func (idx *Index) Remove(id int64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+}
+
+func (idx *Index) remove(id int64) {
+	task, ok := idx.tasks[id]
+	if !ok {
+		return
+	}
+
+	for _, token := range Tokenize(task.Description) {
+		docs := idx.postings[token]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(idx.postings, token)
+		}
+	}
+
+	idx.totalLen -= idx.lengths[id]
+	delete(idx.lengths, id)
+	delete(idx.tasks, id)
+}
+
+// Search returns the tasks matching every term and phrase of the query, ranked by BM25 score.
+// A limit of zero or less returns all matches.
+func (idx *Index) Search(query Query, limit int) (result []types.TaskSearchResult) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	words := query.Terms
+	for _, phrase := range query.Phrases {
+		words = append(words, phrase...)
+	}
+
+	if len(words) == 0 || len(idx.tasks) == 0 {
+		return nil
+	}
+
+	candidates := idx.candidates(words)
+	avgLen := float64(idx.totalLen) / float64(len(idx.tasks))
+
+	for id := range candidates {
+		if !idx.matchPhrases(id, query.Phrases) {
+			continue
+		}
+
+		var score float64
+		for _, word := range words {
+			docs := idx.postings[word]
+			tf := float64(len(docs[id]))
+			idf := math.Log(1 + (float64(len(idx.tasks))-float64(len(docs))+0.5)/(float64(len(docs))+0.5))
+			norm := 1 - bm25B + bm25B*float64(idx.lengths[id])/avgLen
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+
+		result = append(result, types.TaskSearchResult{
+			Task:  idx.tasks[id],
+			Score: score,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Task.ID < result[j].Task.ID
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
+
+// candidates returns the IDs of the tasks containing every word.
+func (idx *Index) candidates(words []string) map[int64]struct{} {
+	result := make(map[int64]struct{})
+	for id := range idx.postings[words[0]] {
+		result[id] = struct{}{}
+	}
+
+	for _, word := range words[1:] {
+		docs := idx.postings[word]
+		for id := range result {
+			if _, ok := docs[id]; !ok {
+				delete(result, id)
+			}
+		}
+	}
+
+	return result
+}
+
+func (idx *Index) matchPhrases(id int64, phrases [][]string) bool {
+	for _, phrase := range phrases {
+		if !idx.matchPhrase(id, phrase) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (idx *Index) matchPhrase(id int64, phrase []string) bool {
+	for _, start := range idx.postings[phrase[0]][id] {
+		matched := true
+		for offset, word := range phrase[1:] {
+			if !containsPosition(idx.postings[word][id], start+offset+1) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsPosition(positions []int, pos int) bool {
+	i := sort.SearchInts(positions, pos)
+	return i < len(positions) && positions[i] == pos
+}
@@ -0,0 +1,101 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/winartodev/go-grpc/types"
+)
+
+func newIndexWith(tasks ...types.Task) *Index {
+	idx := NewIndex()
+	for _, task := range tasks {
+		idx.Add(task)
+	}
+
+	return idx
+}
+
+func resultIDs(results []types.TaskSearchResult) (ids []int64) {
+	for _, result := range results {
+		ids = append(ids, result.Task.ID)
+	}
+
+	return ids
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := newIndexWith(
+		types.Task{ID: 1, Description: "buy fresh milk"},
+		types.Task{ID: 2, Description: "milk the cow, milk the goat"},
+		types.Task{ID: 3, Description: "milk fresh from the farm"},
+		types.Task{ID: 4, Description: "write the report"},
+	)
+
+	tests := []struct {
+		name    string
+		query   string
+		limit   int
+		wantIDs []int64
+	}{
+		{
+			name:    "Ranked By Term Frequency",
+			query:   "milk",
+			wantIDs: []int64{2, 1, 3},
+		},
+		{
+			name:    "All Terms Required",
+			query:   "fresh milk",
+			wantIDs: []int64{1, 3},
+		},
+		{
+			name:    "Phrase Match",
+			query:   `"fresh milk"`,
+			wantIDs: []int64{1},
+		},
+		{
+			name:    "Limit",
+			query:   "milk",
+			limit:   1,
+			wantIDs: []int64{2},
+		},
+		{
+			name:    "No Match",
+			query:   "holiday",
+			wantIDs: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resultIDs(idx.Search(ParseQuery(tt.query), tt.limit))
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("Index.Search() = %v, want %v", got, tt.wantIDs)
+			}
+			for i := range got {
+				if got[i] != tt.wantIDs[i] {
+					t.Errorf("Index.Search() = %v, want %v", got, tt.wantIDs)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestIndex_AddReplacesAndRemove(t *testing.T) {
+	idx := newIndexWith(types.Task{ID: 1, Description: "buy milk"})
+
+	idx.Add(types.Task{ID: 1, Description: "buy bread"})
+	if got := idx.Search(ParseQuery("milk"), 0); len(got) != 0 {
+		t.Errorf("Index.Search() after update = %v, want no results", resultIDs(got))
+	}
+	if got := idx.Search(ParseQuery("bread"), 0); len(got) != 1 || got[0].Task.Description != "buy bread" {
+		t.Errorf("Index.Search() after update = %v, want the updated task", got)
+	}
+
+	idx.Remove(1)
+	if got := idx.Search(ParseQuery("bread"), 0); len(got) != 0 {
+		t.Errorf("Index.Search() after remove = %v, want no results", resultIDs(got))
+	}
+	if len(idx.postings) != 0 || idx.totalLen != 0 {
+		t.Errorf("Index after remove still holds postings %v and length %d", idx.postings, idx.totalLen)
+	}
+}
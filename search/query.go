@@ -0,0 +1,44 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Query is a parsed search query. Every term and every phrase must match for a task to be returned.
+type Query struct {
+	Terms   []string
+	Phrases [][]string
+}
+
+func (q Query) IsEmpty() bool {
+	return len(q.Terms) == 0 && len(q.Phrases) == 0
+}
+
+// ParseQuery splits the raw query into single words and "quoted phrases".
+// An unterminated quote turns the rest of the query into a phrase.
+func ParseQuery(raw string) (result Query) {
+	parts := strings.Split(raw, `"`)
+	for i, part := range parts {
+		tokens := Tokenize(part)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		if i%2 == 1 && len(tokens) > 1 {
+			result.Phrases = append(result.Phrases, tokens)
+			continue
+		}
+
+		result.Terms = append(result.Terms, tokens...)
+	}
+
+	return result
+}
+
+// Tokenize lowercases the text and splits it into words made of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
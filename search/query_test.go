@@ -0,0 +1,78 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	type args struct {
+		raw string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult Query
+	}{
+		{
+			name: "Words Only",
+			args: args{
+				raw: "Buy  Milk!",
+			},
+			wantResult: Query{
+				Terms: []string{"buy", "milk"},
+			},
+		},
+		{
+			name: "Words And Phrase",
+			args: args{
+				raw: `groceries "fresh milk" today`,
+			},
+			wantResult: Query{
+				Terms:   []string{"groceries", "today"},
+				Phrases: [][]string{{"fresh", "milk"}},
+			},
+		},
+		{
+			name: "Single Word Phrase Is A Term",
+			args: args{
+				raw: `"milk"`,
+			},
+			wantResult: Query{
+				Terms: []string{"milk"},
+			},
+		},
+		{
+			name: "Unterminated Phrase",
+			args: args{
+				raw: `buy "fresh milk`,
+			},
+			wantResult: Query{
+				Terms:   []string{"buy"},
+				Phrases: [][]string{{"fresh", "milk"}},
+			},
+		},
+		{
+			name: "Empty Query",
+			args: args{
+				raw: ` "" `,
+			},
+			wantResult: Query{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotResult := ParseQuery(tt.args.raw); !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("ParseQuery() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Café-Au-Lait, 2x; +operators*")
+	want := []string{"café", "au", "lait", "2x", "operators"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/winartodev/go-grpc/storage"
+)
+
+type BlobStorage struct {
+	BasePath string
+}
+
+func NewBlobStorage(basePath string) storage.BlobStorageInterface {
+	return &BlobStorage{
+		BasePath: basePath,
+	}
+}
+
+func (bs *BlobStorage) Put(ctx context.Context, key string, content io.Reader) (err error) {
+	path, err := bs.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// write into a temporary file first so a failed upload never leaves a partial blob behind
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, content)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (bs *BlobStorage) Get(ctx context.Context, key string) (content io.ReadCloser, err error) {
+	path, err := bs.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, storage.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (bs *BlobStorage) Delete(ctx context.Context, key string) (err error) {
+	path, err := bs.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (bs *BlobStorage) path(key string) (string, error) {
+	path := filepath.Join(bs.BasePath, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(bs.BasePath)+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,105 @@
+package local
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/winartodev/go-grpc/storage"
+)
+
+func TestNewBlobStorage(t *testing.T) {
+	type args struct {
+		basePath string
+	}
+	tests := []struct {
+		name string
+		args args
+		want storage.BlobStorageInterface
+	}{
+		{
+			name: "Success Call Local Blob Storage",
+			args: args{
+				basePath: "data",
+			},
+			want: &BlobStorage{
+				BasePath: "data",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewBlobStorage(tt.args.basePath); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBlobStorage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlobStorage_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	bs := &BlobStorage{
+		BasePath: t.TempDir(),
+	}
+
+	err := bs.Put(ctx, "1/abc", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("BlobStorage.Put() error = %v", err)
+	}
+
+	content, err := bs.Get(ctx, "1/abc")
+	if err != nil {
+		t.Fatalf("BlobStorage.Get() error = %v", err)
+	}
+
+	got, _ := io.ReadAll(content)
+	content.Close()
+	if string(got) != "hello" {
+		t.Errorf("BlobStorage.Get() = %s, want %s", got, "hello")
+	}
+
+	err = bs.Delete(ctx, "1/abc")
+	if err != nil {
+		t.Fatalf("BlobStorage.Delete() error = %v", err)
+	}
+
+	_, err = bs.Get(ctx, "1/abc")
+	if err != storage.ErrBlobNotFound {
+		t.Errorf("BlobStorage.Get() error = %v, want %v", err, storage.ErrBlobNotFound)
+	}
+
+	err = bs.Delete(ctx, "1/abc")
+	if err != nil {
+		t.Errorf("BlobStorage.Delete() of a missing blob error = %v, want nil", err)
+	}
+}
+
+func TestBlobStorage_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	bs := &BlobStorage{
+		BasePath: t.TempDir(),
+	}
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{
+			name: "Parent Directory",
+			key:  "../escape",
+		},
+		{
+			name: "Base Path Itself",
+			key:  ".",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := bs.Put(ctx, tt.key, strings.NewReader("x")); err == nil {
+				t.Errorf("BlobStorage.Put() error = nil, want error for key %q", tt.key)
+			}
+		})
+	}
+}
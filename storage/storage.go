@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStorageInterface stores attachment contents by key, independent of where the bytes live.
+type BlobStorageInterface interface {
+	Put(ctx context.Context, key string, content io.Reader) (err error)
+	Get(ctx context.Context, key string) (content io.ReadCloser, err error)
+	Delete(ctx context.Context, key string) (err error)
+}
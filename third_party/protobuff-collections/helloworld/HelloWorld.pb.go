@@ -0,0 +1,216 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.23.4
+// source: helloworld/HelloWorld.proto
+
+package helloworld
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type HelloWorldRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *HelloWorldRequest) Reset() {
+	*x = HelloWorldRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_helloworld_HelloWorld_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelloWorldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelloWorldRequest) ProtoMessage() {}
+
+func (x *HelloWorldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_HelloWorld_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelloWorldRequest.ProtoReflect.Descriptor instead.
+func (*HelloWorldRequest) Descriptor() ([]byte, []int) {
+	return file_helloworld_HelloWorld_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HelloWorldRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type HelloWorldResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *HelloWorldResponse) Reset() {
+	*x = HelloWorldResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_helloworld_HelloWorld_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelloWorldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelloWorldResponse) ProtoMessage() {}
+
+func (x *HelloWorldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_HelloWorld_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelloWorldResponse.ProtoReflect.Descriptor instead.
+func (*HelloWorldResponse) Descriptor() ([]byte, []int) {
+	return file_helloworld_HelloWorld_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HelloWorldResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_helloworld_HelloWorld_proto protoreflect.FileDescriptor
+
+var file_helloworld_HelloWorld_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2f, 0x48, 0x65, 0x6c,
+	0x6c, 0x6f, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x68,
+	0x65, 0x6c, 0x6c, 0x6f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x22, 0x27, 0x0a, 0x11, 0x48, 0x65, 0x6c,
+	0x6c, 0x6f, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x28, 0x0a, 0x12, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x57, 0x6f, 0x72, 0x6c, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x32, 0x5e, 0x0a, 0x0a,
+	0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x50, 0x0a, 0x0d, 0x53, 0x61,
+	0x79, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x1d, 0x2e, 0x68, 0x65,
+	0x6c, 0x6c, 0x6f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x57, 0x6f,
+	0x72, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x68, 0x65, 0x6c,
+	0x6c, 0x6f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x57, 0x6f, 0x72,
+	0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x38, 0x5a, 0x36,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x69, 0x6e, 0x61, 0x72,
+	0x74, 0x6f, 0x64, 0x65, 0x76, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x66, 0x2d,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x68, 0x65, 0x6c, 0x6c,
+	0x6f, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_helloworld_HelloWorld_proto_rawDescOnce sync.Once
+	file_helloworld_HelloWorld_proto_rawDescData = file_helloworld_HelloWorld_proto_rawDesc
+)
+
+func file_helloworld_HelloWorld_proto_rawDescGZIP() []byte {
+	file_helloworld_HelloWorld_proto_rawDescOnce.Do(func() {
+		file_helloworld_HelloWorld_proto_rawDescData = protoimpl.X.CompressGZIP(file_helloworld_HelloWorld_proto_rawDescData)
+	})
+	return file_helloworld_HelloWorld_proto_rawDescData
+}
+
+var file_helloworld_HelloWorld_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_helloworld_HelloWorld_proto_goTypes = []interface{}{
+	(*HelloWorldRequest)(nil),  // 0: helloworld.HelloWorldRequest
+	(*HelloWorldResponse)(nil), // 1: helloworld.HelloWorldResponse
+}
+var file_helloworld_HelloWorld_proto_depIdxs = []int32{
+	0, // 0: helloworld.HelloWorld.SayHelloWorld:input_type -> helloworld.HelloWorldRequest
+	1, // 1: helloworld.HelloWorld.SayHelloWorld:output_type -> helloworld.HelloWorldResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_helloworld_HelloWorld_proto_init() }
+func file_helloworld_HelloWorld_proto_init() {
+	if File_helloworld_HelloWorld_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_helloworld_HelloWorld_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelloWorldRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_helloworld_HelloWorld_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelloWorldResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_helloworld_HelloWorld_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_helloworld_HelloWorld_proto_goTypes,
+		DependencyIndexes: file_helloworld_HelloWorld_proto_depIdxs,
+		MessageInfos:      file_helloworld_HelloWorld_proto_msgTypes,
+	}.Build()
+	File_helloworld_HelloWorld_proto = out.File
+	file_helloworld_HelloWorld_proto_rawDesc = nil
+	file_helloworld_HelloWorld_proto_goTypes = nil
+	file_helloworld_HelloWorld_proto_depIdxs = nil
+}
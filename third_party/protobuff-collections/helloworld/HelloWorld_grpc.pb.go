@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.23.4
+// source: helloworld/HelloWorld.proto
+
+package helloworld
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// HelloWorldClient is the client API for HelloWorld service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HelloWorldClient interface {
+	SayHelloWorld(ctx context.Context, in *HelloWorldRequest, opts ...grpc.CallOption) (*HelloWorldResponse, error)
+}
+
+type helloWorldClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHelloWorldClient(cc grpc.ClientConnInterface) HelloWorldClient {
+	return &helloWorldClient{cc}
+}
+
+func (c *helloWorldClient) SayHelloWorld(ctx context.Context, in *HelloWorldRequest, opts ...grpc.CallOption) (*HelloWorldResponse, error) {
+	out := new(HelloWorldResponse)
+	err := c.cc.Invoke(ctx, "/helloworld.HelloWorld/SayHelloWorld", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HelloWorldServer is the server API for HelloWorld service.
+// All implementations must embed UnimplementedHelloWorldServer
+// for forward compatibility
+type HelloWorldServer interface {
+	SayHelloWorld(context.Context, *HelloWorldRequest) (*HelloWorldResponse, error)
+	mustEmbedUnimplementedHelloWorldServer()
+}
+
+// UnimplementedHelloWorldServer must be embedded to have forward compatible implementations.
+type UnimplementedHelloWorldServer struct {
+}
+
+func (UnimplementedHelloWorldServer) SayHelloWorld(context.Context, *HelloWorldRequest) (*HelloWorldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SayHelloWorld not implemented")
+}
+func (UnimplementedHelloWorldServer) mustEmbedUnimplementedHelloWorldServer() {}
+
+// UnsafeHelloWorldServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HelloWorldServer will
+// result in compilation errors.
+type UnsafeHelloWorldServer interface {
+	mustEmbedUnimplementedHelloWorldServer()
+}
+
+func RegisterHelloWorldServer(s grpc.ServiceRegistrar, srv HelloWorldServer) {
+	s.RegisterService(&HelloWorld_ServiceDesc, srv)
+}
+
+func _HelloWorld_SayHelloWorld_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HelloWorldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelloWorldServer).SayHelloWorld(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/helloworld.HelloWorld/SayHelloWorld",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelloWorldServer).SayHelloWorld(ctx, req.(*HelloWorldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HelloWorld_ServiceDesc is the grpc.ServiceDesc for HelloWorld service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HelloWorld_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "helloworld.HelloWorld",
+	HandlerType: (*HelloWorldServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SayHelloWorld",
+			Handler:    _HelloWorld_SayHelloWorld_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "helloworld/HelloWorld.proto",
+}
@@ -0,0 +1,187 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultReloadInterval is how often the certificate files are checked for changes.
+const DefaultReloadInterval = 30 * time.Second
+
+// ParseMinVersion maps a config value such as "1.2" to its tls version constant. TLS 1.2 is
+// used when the value is empty; older versions are rejected.
+func ParseMinVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported tls min version %q", version)
+	}
+}
+
+// Reloader serves the server certificate and client CA pool from files and picks up
+// rotated files without a restart.
+type Reloader struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	reloader := &Reloader{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: clientCAFile,
+	}
+
+	err := reloader.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// Reload reads the files again. On error the previously loaded certificates stay in use.
+func (r *Reloader) Reload() error {
+	modTimes, err := r.statFiles()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.CertFile, r.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load key pair: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.ClientCAFile)
+		if err != nil {
+			return err
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in %s", r.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Changed reports whether any of the files was modified since the last successful load.
+func (r *Reloader) Changed() bool {
+	modTimes, err := r.statFiles()
+	if err != nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for name, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[name]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Watch reloads the files every interval when they changed, until ctx is done.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.Changed() {
+				continue
+			}
+
+			err := r.Reload()
+			if err != nil {
+				slog.Warn("tls reload failed, keeping previous certificates", "error", err)
+				continue
+			}
+
+			slog.Info("tls certificates reloaded")
+		}
+	}
+}
+
+// TLSConfig returns a server config resolving the current certificates on every handshake.
+// Client certificates are required and verified when a client CA file is configured.
+//
+// The config returned per handshake replaces the outer one entirely, so it is cloned from a
+// base that advertises h2 over ALPN itself: credentials.NewTLS only adds h2 to its own copy
+// of the outer config.
+func (r *Reloader) TLSConfig(minVersion uint16) *tls.Config {
+	base := &tls.Config{
+		MinVersion: minVersion,
+		NextProtos: []string{"h2"},
+	}
+
+	outer := base.Clone()
+	outer.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		config := base.Clone()
+		config.Certificates = []tls.Certificate{*r.cert}
+
+		if r.clientCAs != nil {
+			config.ClientCAs = r.clientCAs
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+
+		return config, nil
+	}
+
+	return outer
+}
+
+func (r *Reloader) statFiles() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	for _, name := range []string{r.CertFile, r.KeyFile, r.ClientCAFile} {
+		if name == "" {
+			continue
+		}
+
+		info, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+
+		modTimes[name] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName names the tracer used by the handler, usecase and repository spans.
+const InstrumentationName = "github.com/winartodev/go-grpc"
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Setup installs the global tracer provider and W3C trace context propagation. Exporter is
+// "otlp", "stdout" or "none"; with "none" spans are still propagated but never recorded.
+// The returned function flushes pending spans and must be called before exiting.
+func Setup(ctx context.Context, exporter string, endpoint string, serviceName string, sampleRatio float64) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var spanExporter sdktrace.SpanExporter
+	switch exporter {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "otlp":
+		options := []otlptracegrpc.Option{otlptracegrpc.WithInsecure()}
+		if endpoint != "" {
+			options = append(options, otlptracegrpc.WithEndpoint(endpoint))
+		}
+		spanExporter, err = otlptracegrpc.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithSampler(sampler(sampleRatio)),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// sampler records sampleRatio of the new traces and follows the decision of the caller for
+// continued ones. A ratio of 0 records no new traces at all.
+func sampler(sampleRatio float64) sdktrace.Sampler {
+	root := sdktrace.TraceIDRatioBased(sampleRatio)
+	switch {
+	case sampleRatio <= 0:
+		root = sdktrace.NeverSample()
+	case sampleRatio >= 1:
+		root = sdktrace.AlwaysSample()
+	}
+
+	return sdktrace.ParentBased(root)
+}
+
+// End records err on the span, if any, and ends it. It is meant to be deferred with the
+// address of the named error result.
+func End(span trace.Span, err *error) {
+	if *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+
+	span.End()
+}
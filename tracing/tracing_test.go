@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+	}{
+		{
+			name:     "None",
+			exporter: "none",
+			wantErr:  false,
+		},
+		{
+			name:     "Default",
+			exporter: "",
+			wantErr:  false,
+		},
+		{
+			name:     "Unknown",
+			exporter: "zipkin",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), tt.exporter, "", "todolist", 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Setup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := shutdown(context.Background()); err != nil {
+					t.Errorf("shutdown() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestSampler(t *testing.T) {
+	tests := []struct {
+		name        string
+		sampleRatio float64
+		want        string
+	}{
+		{
+			name:        "Nothing",
+			sampleRatio: 0,
+			want:        "ParentBased{root:AlwaysOffSampler",
+		},
+		{
+			name:        "Ratio",
+			sampleRatio: 0.25,
+			want:        "ParentBased{root:TraceIDRatioBased{0.25}",
+		},
+		{
+			name:        "Everything",
+			sampleRatio: 1,
+			want:        "ParentBased{root:AlwaysOnSampler",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sampler(tt.sampleRatio).Description(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("sampler() = %v, want %v...", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(InstrumentationName)
+
+	var ok error
+	_, span := tracer.Start(context.Background(), "ok")
+	End(span, &ok)
+
+	failed := errors.New("boom")
+	_, span = tracer.Start(context.Background(), "failed")
+	End(span, &failed)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended spans = %d, want 2", len(spans))
+	}
+	if got := spans[0].Status().Code; got != codes.Unset {
+		t.Errorf("ok span status = %v, want %v", got, codes.Unset)
+	}
+	if got := spans[1].Status(); got.Code != codes.Error || got.Description != "boom" {
+		t.Errorf("failed span status = %v, want error boom", got)
+	}
+}
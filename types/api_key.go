@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+// APIKey is a long-lived credential for service callers. Only the SHA-256 of the key is
+// stored, Prefix keeps its first characters so owners can recognise it.
+type APIKey struct {
+	ID         int64
+	TenantID   string
+	Name       string
+	Prefix     string
+	Hash       string
+	Scopes     []string
+	CreatorID  string
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+	LastUsedIP string
+	CreatedAt  *time.Time
+}
@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+type Attachment struct {
+	ID          int64
+	TaskID      int64
+	Name        string
+	Size        int64
+	ContentType string
+	SHA256      string
+	StorageKey  string
+	CreatedAt   *time.Time
+}
@@ -5,7 +5,25 @@ import "time"
 type Task struct {
 	ID          int64
 	Description string
-	Completed   bool
+	Status      string
+	Completed   bool // derived from Status, kept for clients that predate statuses
+	Rank        string
+	AssigneeID  string
+	CreatorID   string
 	CreatedAt   *time.Time
 	UpdatedAt   *time.Time
 }
+
+// TaskFilter narrows a task listing, empty fields match every task. The *Me flags are
+// resolved against the authenticated caller before reaching the repository.
+type TaskFilter struct {
+	AssigneeID   string
+	CreatorID    string
+	AssignedToMe bool
+	CreatedByMe  bool
+}
+
+type TaskSearchResult struct {
+	Task  Task
+	Score float64
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/authz"
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	"github.com/winartodev/go-grpc/types"
+)
+
+const (
+	apiKeyPrefix       = "tk_"
+	apiKeyPrefixLength = 8
+)
+
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+type APIKeyUsecase struct {
+	APIKeyRepository todoRepository.APIKeyRepositoryInterface
+	Authorizer       authz.AuthorizerInterface
+}
+
+type APIKeyUsecaseInterface interface {
+	Create(ctx context.Context, data types.APIKey) (result *types.APIKey, key string, err error)
+	Revoke(ctx context.Context, id int64) (err error)
+	Authenticate(ctx context.Context, key string, ip string) (principal auth.Principal, err error)
+}
+
+func NewAPIKeyUsecase(apiKeyRepository todoRepository.APIKeyRepositoryInterface, authorizer authz.AuthorizerInterface) APIKeyUsecaseInterface {
+	return &APIKeyUsecase{
+		APIKeyRepository: apiKeyRepository,
+		Authorizer:       authorizer,
+	}
+}
+
+// Create issues a key for the tenant of the caller and returns the secret, which is not
+// stored and cannot be retrieved again.
+func (akuc *APIKeyUsecase) Create(ctx context.Context, data types.APIKey) (result *types.APIKey, key string, err error) {
+	err = akuc.Authorizer.Authorize(ctx, authz.ActionManageAPIKeys, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if data.Name == "" {
+		return nil, "", fmt.Errorf("%w: api key name is required", ErrInvalidArgument)
+	}
+
+	if len(data.Scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: api key needs at least one scope", ErrInvalidArgument)
+	}
+
+	principal, _ := auth.FromContext(ctx)
+	for _, scope := range data.Scopes {
+		if !authz.IsValidAction(scope) {
+			return nil, "", fmt.Errorf("%w: unknown api key scope %q", ErrInvalidArgument, scope)
+		}
+
+		// A scoped caller, such as another api key, cannot hand out more than it holds.
+		if len(principal.Scopes) > 0 && !slices.Contains(principal.Scopes, scope) {
+			return nil, "", fmt.Errorf("%w: cannot grant scope %q the caller does not hold", authz.ErrPermissionDenied, scope)
+		}
+	}
+
+	now := time.Now()
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(now) {
+		return nil, "", fmt.Errorf("%w: api key expiry must be in the future", ErrInvalidArgument)
+	}
+
+	key, err = newAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	data.Prefix = key[:len(apiKeyPrefix)+apiKeyPrefixLength]
+	data.Hash = hashAPIKey(key)
+	data.CreatedAt = &now
+
+	data.CreatorID = principal.UserID
+
+	id, err := akuc.APIKeyRepository.Create(ctx, data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err = akuc.APIKeyRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return result, key, nil
+}
+
+func (akuc *APIKeyUsecase) Revoke(ctx context.Context, id int64) (err error) {
+	err = akuc.Authorizer.Authorize(ctx, authz.ActionManageAPIKeys, nil)
+	if err != nil {
+		return err
+	}
+
+	apiKey, err := akuc.APIKeyRepository.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("api key with id %d was %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return err
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	return akuc.APIKeyRepository.RevokeByIDDB(ctx, id, &now)
+}
+
+// Authenticate resolves a key to its principal and records the use. The principal has the
+// admin role limited to the scopes of the key, in the tenant the key was created in.
+func (akuc *APIKeyUsecase) Authenticate(ctx context.Context, key string, ip string) (principal auth.Principal, err error) {
+	apiKey, err := akuc.APIKeyRepository.GetByHashDB(ctx, hashAPIKey(key))
+	if err == sql.ErrNoRows {
+		return principal, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return principal, err
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt != nil {
+		return principal, fmt.Errorf("%w: key %s was revoked", ErrInvalidAPIKey, apiKey.Prefix)
+	}
+
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(now) {
+		return principal, fmt.Errorf("%w: key %s has expired", ErrInvalidAPIKey, apiKey.Prefix)
+	}
+
+	// usage tracking is for auditing only and must not lock callers out
+	err = akuc.APIKeyRepository.UpdateLastUsedDB(ctx, apiKey.ID, &now, ip)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to record api key use", "api_key_id", apiKey.ID, "error", err)
+	}
+
+	return auth.Principal{
+		UserID:   "apikey:" + strconv.FormatInt(apiKey.ID, 10),
+		Role:     authz.RoleAdmin,
+		TenantID: apiKey.TenantID,
+		Scopes:   apiKey.Scopes,
+	}, nil
+}
+
+func newAPIKey() (string, error) {
+	buf := make([]byte, 24)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return apiKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashAPIKey uses a plain SHA-256, keys are random enough that a slow hash adds nothing.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
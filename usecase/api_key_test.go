@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/stretchr/testify/mock"
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/authz"
+	todoRepositoryMock "github.com/winartodev/go-grpc/repository/mysql/mocks"
+	"github.com/winartodev/go-grpc/types"
+)
+
+var apiKeyDataMock = types.APIKey{
+	ID:        1,
+	TenantID:  "acme",
+	Name:      "nightly export",
+	Prefix:    "tk_0123abcd",
+	Hash:      hashAPIKey("tk_0123abcdef"),
+	Scopes:    []string{"read"},
+	CreatorID: "alice",
+	CreatedAt: &mockTime,
+}
+
+func TestNewAPIKeyUsecase(t *testing.T) {
+	repository := new(todoRepositoryMock.APIKeyRepositoryInterface)
+	want := &APIKeyUsecase{
+		APIKeyRepository: repository,
+		Authorizer:       authz.AllowAll(),
+	}
+
+	if got := NewAPIKeyUsecase(repository, authz.AllowAll()); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAPIKeyUsecase() = %v, want %v", got, want)
+	}
+}
+
+func TestAPIKeyUsecase_Create(t *testing.T) {
+	monkey.Patch(time.Now, func() time.Time {
+		return mockTime
+	})
+	defer monkey.UnpatchAll()
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{UserID: "alice", Role: authz.RoleAdmin, TenantID: "acme"})
+	editorCtx := auth.NewContext(context.Background(), auth.Principal{UserID: "bob", Role: authz.RoleEditor, TenantID: "acme"})
+	keyManagerCtx := auth.NewContext(context.Background(), auth.Principal{UserID: "alice", Role: authz.RoleAdmin, TenantID: "acme", Scopes: []string{string(authz.ActionManageAPIKeys)}})
+	past := mockTime.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		data      types.APIKey
+		wantErr   bool
+		wantErrIs error
+		mock      func(repository *todoRepositoryMock.APIKeyRepositoryInterface)
+	}{
+		{
+			name: "Success Create API Key",
+			ctx:  ctx,
+			data: types.APIKey{Name: "nightly export", Scopes: []string{"read"}},
+			mock: func(repository *todoRepositoryMock.APIKeyRepositoryInterface) {
+				repository.On("Create", ctx, mock.MatchedBy(func(data types.APIKey) bool {
+					return data.CreatorID == "alice" && strings.HasPrefix(data.Prefix, apiKeyPrefix) && len(data.Hash) == 64
+				})).Return(int64(1), nil).Once()
+				repository.On("GetByID", ctx, int64(1)).Return(&apiKeyDataMock, nil).Once()
+			},
+		},
+		{
+			name:    "Editor Cannot Create API Key",
+			ctx:     editorCtx,
+			data:    types.APIKey{Name: "nightly export", Scopes: []string{"read"}},
+			wantErr: true,
+		},
+		{
+			name:    "Missing Scopes",
+			ctx:     ctx,
+			data:    types.APIKey{Name: "nightly export"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Scope",
+			ctx:     ctx,
+			data:    types.APIKey{Name: "nightly export", Scopes: []string{"everything"}},
+			wantErr: true,
+		},
+		{
+			name: "Scoped Caller Grants Held Scope",
+			ctx:  keyManagerCtx,
+			data: types.APIKey{Name: "key rotation", Scopes: []string{"manage_api_keys"}},
+			mock: func(repository *todoRepositoryMock.APIKeyRepositoryInterface) {
+				repository.On("Create", keyManagerCtx, mock.Anything).Return(int64(1), nil).Once()
+				repository.On("GetByID", keyManagerCtx, int64(1)).Return(&apiKeyDataMock, nil).Once()
+			},
+		},
+		{
+			name:      "Scoped Caller Cannot Grant Other Scope",
+			ctx:       keyManagerCtx,
+			data:      types.APIKey{Name: "cleanup", Scopes: []string{"delete"}},
+			wantErr:   true,
+			wantErrIs: authz.ErrPermissionDenied,
+		},
+		{
+			name:    "Expiry In The Past",
+			ctx:     ctx,
+			data:    types.APIKey{Name: "nightly export", Scopes: []string{"read"}, ExpiresAt: &past},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := new(todoRepositoryMock.APIKeyRepositoryInterface)
+			if tt.mock != nil {
+				tt.mock(repository)
+			}
+
+			akuc := &APIKeyUsecase{
+				APIKeyRepository: repository,
+				Authorizer:       authz.NewRoleAuthorizer(authz.RoleViewer),
+			}
+			gotResult, gotKey, err := akuc.Create(tt.ctx, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("APIKeyUsecase.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("APIKeyUsecase.Create() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(gotResult, &apiKeyDataMock) {
+				t.Errorf("APIKeyUsecase.Create() = %v, want %v", gotResult, &apiKeyDataMock)
+			}
+			if !strings.HasPrefix(gotKey, apiKeyPrefix) {
+				t.Errorf("APIKeyUsecase.Create() key = %v, want prefix %v", gotKey, apiKeyPrefix)
+			}
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeyUsecase_Revoke(t *testing.T) {
+	monkey.Patch(time.Now, func() time.Time {
+		return mockTime
+	})
+	defer monkey.UnpatchAll()
+
+	ctx := context.Background()
+	repository := new(todoRepositoryMock.APIKeyRepositoryInterface)
+	repository.On("GetByID", ctx, int64(1)).Return(&apiKeyDataMock, nil)
+	repository.On("RevokeByIDDB", ctx, int64(1), &mockTime).Return(nil).Once()
+	repository.On("GetByID", ctx, int64(2)).Return(nil, sql.ErrNoRows)
+
+	akuc := &APIKeyUsecase{
+		APIKeyRepository: repository,
+		Authorizer:       authz.AllowAll(),
+	}
+	if err := akuc.Revoke(ctx, 1); err != nil {
+		t.Errorf("APIKeyUsecase.Revoke() error = %v", err)
+	}
+	if err := akuc.Revoke(ctx, 2); err == nil {
+		t.Errorf("APIKeyUsecase.Revoke() error = nil, want not found")
+	}
+	repository.AssertExpectations(t)
+}
+
+func TestAPIKeyUsecase_Authenticate(t *testing.T) {
+	monkey.Patch(time.Now, func() time.Time {
+		return mockTime
+	})
+	defer monkey.UnpatchAll()
+
+	ctx := context.Background()
+	past := mockTime.Add(-time.Hour)
+
+	revoked := apiKeyDataMock
+	revoked.RevokedAt = &past
+
+	expired := apiKeyDataMock
+	expired.ExpiresAt = &past
+
+	tests := []struct {
+		name    string
+		found   *types.APIKey
+		findErr error
+		want    auth.Principal
+		wantErr bool
+	}{
+		{
+			name:  "Valid Key",
+			found: &apiKeyDataMock,
+			want:  auth.Principal{UserID: "apikey:1", Role: authz.RoleAdmin, TenantID: "acme", Scopes: []string{"read"}},
+		},
+		{
+			name:    "Unknown Key",
+			findErr: sql.ErrNoRows,
+			wantErr: true,
+		},
+		{
+			name:    "Revoked Key",
+			found:   &revoked,
+			wantErr: true,
+		},
+		{
+			name:    "Expired Key",
+			found:   &expired,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := new(todoRepositoryMock.APIKeyRepositoryInterface)
+			repository.On("GetByHashDB", ctx, hashAPIKey("tk_0123abcdef")).Return(tt.found, tt.findErr)
+			if !tt.wantErr {
+				repository.On("UpdateLastUsedDB", ctx, int64(1), &mockTime, "10.0.0.7").Return(errors.New("db down")).Once()
+			}
+
+			akuc := &APIKeyUsecase{
+				APIKeyRepository: repository,
+				Authorizer:       authz.AllowAll(),
+			}
+			got, err := akuc.Authenticate(ctx, "tk_0123abcdef", "10.0.0.7")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("APIKeyUsecase.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidAPIKey) {
+				t.Errorf("APIKeyUsecase.Authenticate() error = %v, want %v", err, ErrInvalidAPIKey)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("APIKeyUsecase.Authenticate() = %v, want %v", got, tt.want)
+			}
+			repository.AssertExpectations(t)
+		})
+	}
+}
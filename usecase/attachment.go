@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/winartodev/go-grpc/authz"
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	"github.com/winartodev/go-grpc/storage"
+	"github.com/winartodev/go-grpc/types"
+)
+
+const (
+	DefaultMaxAttachmentSize = 10 << 20
+
+	defaultAttachmentContentType = "application/octet-stream"
+)
+
+var ErrAttachmentTooLarge = errors.New("attachment exceeds the maximum allowed size")
+
+type AttachmentUsecase struct {
+	AttachmentRepository todoRepository.AttachmentRepositoryInterface
+	TodoRepository       todoRepository.TodoRepositoryInterface
+	BlobStorage          storage.BlobStorageInterface
+	MaxSize              int64
+	Authorizer           authz.AuthorizerInterface
+}
+
+type AttachmentUsecaseInterface interface {
+	Upload(ctx context.Context, data types.Attachment, content io.Reader) (result *types.Attachment, err error)
+	Download(ctx context.Context, id int64) (result *types.Attachment, content io.ReadCloser, err error)
+	DeleteByTaskID(ctx context.Context, taskID int64, deleteTask func(ctx context.Context) error) (err error)
+}
+
+func NewAttachmentUsecase(attachmentRepository todoRepository.AttachmentRepositoryInterface, todoRepository todoRepository.TodoRepositoryInterface, blobStorage storage.BlobStorageInterface, maxSize int64, authorizer authz.AuthorizerInterface) AttachmentUsecaseInterface {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxAttachmentSize
+	}
+
+	return &AttachmentUsecase{
+		AttachmentRepository: attachmentRepository,
+		TodoRepository:       todoRepository,
+		BlobStorage:          blobStorage,
+		MaxSize:              maxSize,
+		Authorizer:           authorizer,
+	}
+}
+
+func (auc *AttachmentUsecase) Upload(ctx context.Context, data types.Attachment, content io.Reader) (result *types.Attachment, err error) {
+	if data.Name == "" {
+		return nil, fmt.Errorf("%w: attachment name is required", ErrInvalidArgument)
+	}
+
+	task, err := auc.TodoRepository.GetByID(ctx, data.TaskID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if task == nil {
+		return nil, fmt.Errorf("task with id %d was %w", data.TaskID, ErrNotFound)
+	}
+
+	err = auc.Authorizer.Authorize(ctx, authz.ActionUpdate, task)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := newStorageKey(data.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	reader := &sizeLimitedReader{
+		Reader: io.TeeReader(content, hash),
+		Limit:  auc.MaxSize,
+	}
+
+	err = auc.BlobStorage.Put(ctx, key, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	data.Size = reader.N
+	data.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	data.StorageKey = key
+	data.CreatedAt = &now
+
+	if data.ContentType == "" {
+		data.ContentType = defaultAttachmentContentType
+	}
+
+	id, err := auc.AttachmentRepository.Create(ctx, data)
+	if err != nil {
+		auc.BlobStorage.Delete(ctx, key)
+		return nil, err
+	}
+
+	return auc.AttachmentRepository.GetByID(ctx, id)
+}
+
+func (auc *AttachmentUsecase) Download(ctx context.Context, id int64) (result *types.Attachment, content io.ReadCloser, err error) {
+	result, err = auc.AttachmentRepository.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, nil, fmt.Errorf("attachment with id %d was %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task, err := auc.TodoRepository.GetByID(ctx, result.TaskID)
+	if err == sql.ErrNoRows {
+		return nil, nil, fmt.Errorf("task with id %d was %w", result.TaskID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = auc.Authorizer.Authorize(ctx, authz.ActionRead, task)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err = auc.BlobStorage.Get(ctx, result.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, content, nil
+}
+
+// DeleteByTaskID deletes the task with deleteTask, which also deletes the attachment rows
+// through their foreign key, and only then removes the blobs. A failed delete so never leaves
+// rows pointing at missing blobs, and a blob that cannot be removed is logged and left behind.
+func (auc *AttachmentUsecase) DeleteByTaskID(ctx context.Context, taskID int64, deleteTask func(ctx context.Context) error) (err error) {
+	attachments, err := auc.AttachmentRepository.GetByTaskIDDB(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	err = deleteTask(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if err := auc.BlobStorage.Delete(ctx, attachment.StorageKey); err != nil {
+			slog.WarnContext(ctx, "failed to delete attachment blob", "attachment_id", attachment.ID, "storage_key", attachment.StorageKey, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func newStorageKey(taskID int64) (string, error) {
+	buf := make([]byte, 16)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d/%s", taskID, hex.EncodeToString(buf)), nil
+}
+
+// sizeLimitedReader counts the bytes read and fails once more than Limit bytes were seen,
+// so oversized uploads are rejected while streaming instead of after being stored.
+type sizeLimitedReader struct {
+	Reader io.Reader
+	Limit  int64
+	N      int64
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (n int, err error) {
+	n, err = r.Reader.Read(p)
+	r.N += int64(n)
+	if r.N > r.Limit {
+		return n, ErrAttachmentTooLarge
+	}
+
+	return n, err
+}
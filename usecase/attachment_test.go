@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/authz"
+	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	todoRepositoryMock "github.com/winartodev/go-grpc/repository/mysql/mocks"
+	"github.com/winartodev/go-grpc/storage"
+	storageMock "github.com/winartodev/go-grpc/storage/mocks"
+	"github.com/winartodev/go-grpc/types"
+)
+
+type attachmentUsecaseMock struct {
+	AttachmentRepository *todoRepositoryMock.AttachmentRepositoryInterface
+	TodoRepository       *todoRepositoryMock.TodoRepositoryInterface
+	BlobStorage          *storageMock.BlobStorageInterface
+}
+
+func newAttachmentUsecaseMock() attachmentUsecaseMock {
+	return attachmentUsecaseMock{
+		AttachmentRepository: new(todoRepositoryMock.AttachmentRepositoryInterface),
+		TodoRepository:       new(todoRepositoryMock.TodoRepositoryInterface),
+		BlobStorage:          new(storageMock.BlobStorageInterface),
+	}
+}
+
+var attachmentDataMock = types.Attachment{
+	ID:          1,
+	TaskID:      1,
+	Name:        "notes.txt",
+	Size:        5,
+	ContentType: "text/plain",
+	SHA256:      "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+	StorageKey:  "1/abc",
+	CreatedAt:   &mockTime,
+}
+
+func TestNewAttachmentUsecase(t *testing.T) {
+	type args struct {
+		attachmentRepository *todoRepository.AttachmentRepository
+		todoRepository       *todoRepository.TodoRepository
+		blobStorage          storage.BlobStorageInterface
+		maxSize              int64
+		authorizer           authz.AuthorizerInterface
+	}
+	tests := []struct {
+		name string
+		args args
+		want AttachmentUsecaseInterface
+	}{
+		{
+			name: "Configured Max Size",
+			args: args{
+				attachmentRepository: &todoRepository.AttachmentRepository{},
+				todoRepository:       &todoRepository.TodoRepository{},
+				maxSize:              1024,
+				authorizer:           authz.AllowAll(),
+			},
+			want: &AttachmentUsecase{
+				AttachmentRepository: &todoRepository.AttachmentRepository{},
+				TodoRepository:       &todoRepository.TodoRepository{},
+				MaxSize:              1024,
+				Authorizer:           authz.AllowAll(),
+			},
+		},
+		{
+			name: "Default Max Size",
+			args: args{
+				attachmentRepository: &todoRepository.AttachmentRepository{},
+				todoRepository:       &todoRepository.TodoRepository{},
+			},
+			want: &AttachmentUsecase{
+				AttachmentRepository: &todoRepository.AttachmentRepository{},
+				TodoRepository:       &todoRepository.TodoRepository{},
+				MaxSize:              DefaultMaxAttachmentSize,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAttachmentUsecase(tt.args.attachmentRepository, tt.args.todoRepository, tt.args.blobStorage, tt.args.maxSize, tt.args.authorizer); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAttachmentUsecase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentUsecase_Upload(t *testing.T) {
+	ctx := context.Background()
+
+	monkey.Patch(time.Now, func() time.Time {
+		return mockTime
+	})
+	defer monkey.UnpatchAll()
+
+	// drain the content like a real storage would, so size and checksum get computed
+	putBlob := func(ctx context.Context, key string, content io.Reader) error {
+		_, err := io.Copy(io.Discard, content)
+		return err
+	}
+
+	type args struct {
+		ctx     context.Context
+		data    types.Attachment
+		content io.Reader
+	}
+	tests := []struct {
+		name       string
+		maxSize    int64
+		args       args
+		wantResult *types.Attachment
+		wantErr    error
+		mock       func(m attachmentUsecaseMock)
+	}{
+		{
+			name:    "Success Upload Attachment",
+			maxSize: 1024,
+			args: args{
+				ctx: ctx,
+				data: types.Attachment{
+					TaskID:      1,
+					Name:        "notes.txt",
+					ContentType: "text/plain",
+				},
+				content: strings.NewReader("hello"),
+			},
+			wantResult: &attachmentDataMock,
+			mock: func(m attachmentUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(&dataMock, nil)
+				m.BlobStorage.On("Put", ctx, mock.AnythingOfType("string"), mock.Anything).Return(putBlob)
+				m.AttachmentRepository.On("Create", ctx, mock.MatchedBy(func(data types.Attachment) bool {
+					return data.Size == attachmentDataMock.Size && data.SHA256 == attachmentDataMock.SHA256 && strings.HasPrefix(data.StorageKey, "1/")
+				})).Return(int64(1), nil)
+				m.AttachmentRepository.On("GetByID", ctx, int64(1)).Return(&attachmentDataMock, nil)
+			},
+		},
+		{
+			name:    "Attachment Too Large",
+			maxSize: 4,
+			args: args{
+				ctx: ctx,
+				data: types.Attachment{
+					TaskID: 1,
+					Name:   "notes.txt",
+				},
+				content: strings.NewReader("hello"),
+			},
+			wantResult: nil,
+			wantErr:    ErrAttachmentTooLarge,
+			mock: func(m attachmentUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(&dataMock, nil)
+				m.BlobStorage.On("Put", ctx, mock.AnythingOfType("string"), mock.Anything).Return(putBlob)
+			},
+		},
+		{
+			name:    "Task Not Found",
+			maxSize: 1024,
+			args: args{
+				ctx: ctx,
+				data: types.Attachment{
+					TaskID: 2,
+					Name:   "notes.txt",
+				},
+				content: strings.NewReader("hello"),
+			},
+			wantResult: nil,
+			wantErr:    fmt.Errorf("task with id 2 was %w", ErrNotFound),
+			mock: func(m attachmentUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(2)).Return(nil, sql.ErrNoRows)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAttachmentUsecaseMock()
+			tt.mock(m)
+
+			auc := &AttachmentUsecase{
+				AttachmentRepository: m.AttachmentRepository,
+				TodoRepository:       m.TodoRepository,
+				BlobStorage:          m.BlobStorage,
+				MaxSize:              tt.maxSize,
+				Authorizer:           authz.AllowAll(),
+			}
+			gotResult, err := auc.Upload(tt.args.ctx, tt.args.data, tt.args.content)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("AttachmentUsecase.Upload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("AttachmentUsecase.Upload() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestAttachmentUsecase_Download(t *testing.T) {
+	ctx := context.Background()
+	m := newAttachmentUsecaseMock()
+
+	content := io.NopCloser(strings.NewReader("hello"))
+	m.AttachmentRepository.On("GetByID", ctx, int64(1)).Return(&attachmentDataMock, nil)
+	m.TodoRepository.On("GetByID", ctx, attachmentDataMock.TaskID).Return(&dataMock, nil)
+	m.BlobStorage.On("Get", ctx, attachmentDataMock.StorageKey).Return(content, nil)
+
+	auc := &AttachmentUsecase{
+		AttachmentRepository: m.AttachmentRepository,
+		TodoRepository:       m.TodoRepository,
+		BlobStorage:          m.BlobStorage,
+		Authorizer:           authz.AllowAll(),
+	}
+
+	gotResult, gotContent, err := auc.Download(ctx, 1)
+	if err != nil {
+		t.Fatalf("AttachmentUsecase.Download() error = %v", err)
+	}
+	if !reflect.DeepEqual(gotResult, &attachmentDataMock) {
+		t.Errorf("AttachmentUsecase.Download() = %v, want %v", gotResult, &attachmentDataMock)
+	}
+	if gotContent != content {
+		t.Errorf("AttachmentUsecase.Download() content = %v, want %v", gotContent, content)
+	}
+}
+
+func TestAttachmentUsecase_DeleteByTaskID(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		deleteTaskErr error
+		blobErr       error
+		wantErr       bool
+		wantBlobCalls int
+	}{
+		{
+			name:          "Blobs Removed After Task",
+			wantBlobCalls: 1,
+		},
+		{
+			name:          "Failed Task Delete Keeps Blobs",
+			deleteTaskErr: errors.New("lock wait timeout"),
+			wantErr:       true,
+		},
+		{
+			name:          "Failed Blob Delete Is Ignored",
+			blobErr:       errors.New("permission denied"),
+			wantBlobCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAttachmentUsecaseMock()
+			taskDeleted := false
+
+			m.AttachmentRepository.On("GetByTaskIDDB", ctx, int64(1)).Return([]types.Attachment{attachmentDataMock}, nil)
+			m.BlobStorage.On("Delete", ctx, attachmentDataMock.StorageKey).Return(tt.blobErr).Run(func(args mock.Arguments) {
+				if !taskDeleted {
+					t.Error("blob deleted before the task")
+				}
+			})
+
+			auc := &AttachmentUsecase{
+				AttachmentRepository: m.AttachmentRepository,
+				BlobStorage:          m.BlobStorage,
+			}
+
+			err := auc.DeleteByTaskID(ctx, 1, func(ctx context.Context) error {
+				taskDeleted = true
+				return tt.deleteTaskErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AttachmentUsecase.DeleteByTaskID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			m.BlobStorage.AssertNumberOfCalls(t, "Delete", tt.wantBlobCalls)
+		})
+	}
+}
+
+// TestAttachmentUsecase_OtherTenant runs the usecase on the MySQL repositories, so the tenant
+// scoping of the queries themselves is what hides the attachments of another tenant.
+func TestAttachmentUsecase_OtherTenant(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), auth.Principal{UserID: "mallory", TenantID: "globex"})
+
+	tests := []struct {
+		name string
+		mock func(dbmock sqlmock.Sqlmock)
+		call func(auc *AttachmentUsecase) error
+	}{
+		{
+			name: "Download",
+			mock: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectQuery(regexp.QuoteMeta(todoRepository.GetAttachmentByID)).
+					WithArgs("globex", int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "name", "size", "content_type", "sha256", "storage_key", "created_at"}))
+			},
+			call: func(auc *AttachmentUsecase) error {
+				_, _, err := auc.Download(ctx, 1)
+				return err
+			},
+		},
+		{
+			name: "Upload",
+			mock: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectQuery(regexp.QuoteMeta(todoRepository.GetTaskByID)).
+					WithArgs("globex", int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at"}))
+			},
+			call: func(auc *AttachmentUsecase) error {
+				_, err := auc.Upload(ctx, types.Attachment{TaskID: 1, Name: "notes.txt"}, strings.NewReader("hello"))
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbmock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			tt.mock(dbmock)
+
+			blobStorage := new(storageMock.BlobStorageInterface)
+			auc := &AttachmentUsecase{
+				AttachmentRepository: todoRepository.NewAttachmentRepository(db),
+				TodoRepository:       todoRepository.NewTodoRepository(db),
+				BlobStorage:          blobStorage,
+				Authorizer:           authz.AllowAll(),
+			}
+
+			if err := tt.call(auc); !errors.Is(err, ErrNotFound) {
+				t.Errorf("error = %v, want %v", err, ErrNotFound)
+			}
+
+			if err := dbmock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			blobStorage.AssertExpectations(t)
+		})
+	}
+}
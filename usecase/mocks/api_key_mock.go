@@ -0,0 +1,103 @@
+// Code generated by mockery v2.32.4. DO NOT EDIT.
+
+package todousecasemock
+
+import (
+	context "context"
+
+	auth "github.com/winartodev/go-grpc/auth"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/winartodev/go-grpc/types"
+)
+
+// APIKeyUsecaseInterface is an autogenerated mock type for the APIKeyUsecaseInterface type
+type APIKeyUsecaseInterface struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, key, ip
+func (_m *APIKeyUsecaseInterface) Authenticate(ctx context.Context, key string, ip string) (auth.Principal, error) {
+	ret := _m.Called(ctx, key, ip)
+
+	var r0 auth.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (auth.Principal, error)); ok {
+		return rf(ctx, key, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) auth.Principal); ok {
+		r0 = rf(ctx, key, ip)
+	} else {
+		r0 = ret.Get(0).(auth.Principal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, data
+func (_m *APIKeyUsecaseInterface) Create(ctx context.Context, data types.APIKey) (*types.APIKey, string, error) {
+	ret := _m.Called(ctx, data)
+
+	var r0 *types.APIKey
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.APIKey) (*types.APIKey, string, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.APIKey) *types.APIKey); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.APIKey) string); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, types.APIKey) error); ok {
+		r2 = rf(ctx, data)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Revoke provides a mock function with given fields: ctx, id
+func (_m *APIKeyUsecaseInterface) Revoke(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAPIKeyUsecaseInterface creates a new instance of APIKeyUsecaseInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyUsecaseInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyUsecaseInterface {
+	mock := &APIKeyUsecaseInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,106 @@
+// Code generated by mockery v2.32.4. DO NOT EDIT.
+
+package todousecasemock
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/winartodev/go-grpc/types"
+)
+
+// AttachmentUsecaseInterface is an autogenerated mock type for the AttachmentUsecaseInterface type
+type AttachmentUsecaseInterface struct {
+	mock.Mock
+}
+
+// DeleteByTaskID provides a mock function with given fields: ctx, taskID, deleteTask
+func (_m *AttachmentUsecaseInterface) DeleteByTaskID(ctx context.Context, taskID int64, deleteTask func(context.Context) error) error {
+	ret := _m.Called(ctx, taskID, deleteTask)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, func(context.Context) error) error); ok {
+		r0 = rf(ctx, taskID, deleteTask)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Download provides a mock function with given fields: ctx, id
+func (_m *AttachmentUsecaseInterface) Download(ctx context.Context, id int64) (*types.Attachment, io.ReadCloser, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *types.Attachment
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*types.Attachment, io.ReadCloser, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *types.Attachment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) io.ReadCloser); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Upload provides a mock function with given fields: ctx, data, content
+func (_m *AttachmentUsecaseInterface) Upload(ctx context.Context, data types.Attachment, content io.Reader) (*types.Attachment, error) {
+	ret := _m.Called(ctx, data, content)
+
+	var r0 *types.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Attachment, io.Reader) (*types.Attachment, error)); ok {
+		return rf(ctx, data, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.Attachment, io.Reader) *types.Attachment); ok {
+		r0 = rf(ctx, data, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.Attachment, io.Reader) error); ok {
+		r1 = rf(ctx, data, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAttachmentUsecaseInterface creates a new instance of AttachmentUsecaseInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentUsecaseInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentUsecaseInterface {
+	mock := &AttachmentUsecaseInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,6 +14,32 @@ type TodoUsecaseInterface struct {
 	mock.Mock
 }
 
+// Assign provides a mock function with given fields: ctx, id, assigneeID
+func (_m *TodoUsecaseInterface) Assign(ctx context.Context, id int64, assigneeID string) (*types.Task, error) {
+	ret := _m.Called(ctx, id, assigneeID)
+
+	var r0 *types.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (*types.Task, error)); ok {
+		return rf(ctx, id, assigneeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *types.Task); ok {
+		r0 = rf(ctx, id, assigneeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, id, assigneeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, data
 func (_m *TodoUsecaseInterface) Create(ctx context.Context, data types.Task) (*types.Task, error) {
 	ret := _m.Called(ctx, data)
@@ -54,25 +80,25 @@ func (_m *TodoUsecaseInterface) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *TodoUsecaseInterface) GetAll(ctx context.Context) ([]types.Task, error) {
-	ret := _m.Called(ctx)
+// GetAll provides a mock function with given fields: ctx, filter
+func (_m *TodoUsecaseInterface) GetAll(ctx context.Context, filter types.TaskFilter) ([]types.Task, error) {
+	ret := _m.Called(ctx, filter)
 
 	var r0 []types.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]types.Task, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, types.TaskFilter) ([]types.Task, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []types.Task); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, types.TaskFilter) []types.Task); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]types.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, types.TaskFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -106,6 +132,58 @@ func (_m *TodoUsecaseInterface) GetByID(ctx context.Context, id int64) (*types.T
 	return r0, r1
 }
 
+// Move provides a mock function with given fields: ctx, id, beforeID, afterID
+func (_m *TodoUsecaseInterface) Move(ctx context.Context, id int64, beforeID int64, afterID int64) (*types.Task, error) {
+	ret := _m.Called(ctx, id, beforeID, afterID)
+
+	var r0 *types.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (*types.Task, error)); ok {
+		return rf(ctx, id, beforeID, afterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) *types.Task); ok {
+		r0 = rf(ctx, id, beforeID, afterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, id, beforeID, afterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, query, limit
+func (_m *TodoUsecaseInterface) Search(ctx context.Context, query string, limit int) ([]types.TaskSearchResult, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	var r0 []types.TaskSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]types.TaskSearchResult, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []types.TaskSearchResult); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.TaskSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, id, data
 func (_m *TodoUsecaseInterface) Update(ctx context.Context, id int64, data types.Task) (*types.Task, error) {
 	ret := _m.Called(ctx, id, data)
@@ -3,38 +3,108 @@ package usecase
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/authz"
+	"github.com/winartodev/go-grpc/rank"
 	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
+	"github.com/winartodev/go-grpc/search"
 	"github.com/winartodev/go-grpc/types"
+	"github.com/winartodev/go-grpc/workflow"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+
+	// maxRankAttempts bounds the retries when a concurrent create or move took the rank first.
+	maxRankAttempts = 3
+)
+
+var (
+	// ErrNotFound is wrapped by the errors for tasks, attachments and api keys that do not
+	// exist or belong to another tenant.
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalidArgument is wrapped by the errors for requests that cannot succeed as sent,
+	// whatever the state of the tasks.
+	ErrInvalidArgument = errors.New("invalid argument")
 )
 
 type TodoUsecase struct {
-	TodoRepository todoRepository.TodoRepositoryInterface
+	TodoRepository    todoRepository.TodoRepositoryInterface
+	AttachmentUsecase AttachmentUsecaseInterface
+	Workflow          *workflow.Workflow
+	Authorizer        authz.AuthorizerInterface
 }
 
 type TodoUsecaseInterface interface {
 	Create(ctx context.Context, data types.Task) (result *types.Task, err error)
 	GetByID(ctx context.Context, id int64) (result *types.Task, err error)
-	GetAll(ctx context.Context) (result []types.Task, err error)
+	GetAll(ctx context.Context, filter types.TaskFilter) (result []types.Task, err error)
+	Search(ctx context.Context, query string, limit int) (result []types.TaskSearchResult, err error)
 	Update(ctx context.Context, id int64, data types.Task) (result *types.Task, err error)
+	Move(ctx context.Context, id int64, beforeID int64, afterID int64) (result *types.Task, err error)
+	Assign(ctx context.Context, id int64, assigneeID string) (result *types.Task, err error)
 	Delete(ctx context.Context, id int64) (err error)
 }
 
-func NewTodoUsecase(todoRepository todoRepository.TodoRepositoryInterface) TodoUsecaseInterface {
+func NewTodoUsecase(todoRepository todoRepository.TodoRepositoryInterface, attachmentUsecase AttachmentUsecaseInterface, workflow *workflow.Workflow, authorizer authz.AuthorizerInterface) TodoUsecaseInterface {
 	return &TodoUsecase{
-		TodoRepository: todoRepository,
+		TodoRepository:    todoRepository,
+		AttachmentUsecase: attachmentUsecase,
+		Workflow:          workflow,
+		Authorizer:        authorizer,
 	}
 }
 
 func (tuc *TodoUsecase) Create(ctx context.Context, data types.Task) (result *types.Task, err error) {
+	err = tuc.Authorizer.Authorize(ctx, authz.ActionCreate, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Status == "" {
+		data.Status = tuc.Workflow.StatusFromCompleted(tuc.Workflow.Initial, data.Completed)
+	}
+
+	if !tuc.Workflow.IsValid(data.Status) {
+		return nil, fmt.Errorf("%w: unknown task status %q", ErrInvalidArgument, data.Status)
+	}
+
+	data.Completed = tuc.Workflow.IsCompleted(data.Status)
+
+	data.CreatorID = ""
+	if principal, ok := auth.FromContext(ctx); ok {
+		data.CreatorID = principal.UserID
+	}
+
 	now := time.Now()
 	data.CreatedAt = &now
 
-	id, err := tuc.TodoRepository.Create(ctx, data)
-	if err != nil {
-		return nil, err
+	// The unique (tenant_id, sort_rank) index rejects a rank taken by a concurrent create,
+	// in which case the last rank is read again.
+	var id int64
+	for attempt := 1; ; attempt++ {
+		lastRank, err := tuc.TodoRepository.GetLastRankDB(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		data.Rank = rank.After(lastRank)
+
+		id, err = tuc.TodoRepository.Create(ctx, data)
+		if errors.Is(err, todoRepository.ErrDuplicateRank) && attempt < maxRankAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		break
 	}
 
 	result, err = tuc.GetByID(ctx, id)
@@ -46,11 +116,66 @@ func (tuc *TodoUsecase) Create(ctx context.Context, data types.Task) (result *ty
 }
 
 func (tuc *TodoUsecase) GetByID(ctx context.Context, id int64) (result *types.Task, err error) {
-	return tuc.TodoRepository.GetByID(ctx, id)
+	result, err = tuc.TodoRepository.GetByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("task with id %d was %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = tuc.Authorizer.Authorize(ctx, authz.ActionRead, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-func (tuc *TodoUsecase) GetAll(ctx context.Context) (result []types.Task, err error) {
-	return tuc.TodoRepository.GetAllTaskDB(ctx)
+func (tuc *TodoUsecase) GetAll(ctx context.Context, filter types.TaskFilter) (result []types.Task, err error) {
+	err = tuc.Authorizer.Authorize(ctx, authz.ActionRead, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.AssignedToMe || filter.CreatedByMe {
+		principal, ok := auth.FromContext(ctx)
+		if !ok {
+			return nil, fmt.Errorf("%w: filtering on the caller requires an authenticated request", ErrInvalidArgument)
+		}
+
+		if filter.AssignedToMe {
+			filter.AssigneeID = principal.UserID
+		}
+
+		if filter.CreatedByMe {
+			filter.CreatorID = principal.UserID
+		}
+	}
+
+	return tuc.TodoRepository.GetAllTaskDB(ctx, filter)
+}
+
+func (tuc *TodoUsecase) Search(ctx context.Context, query string, limit int) (result []types.TaskSearchResult, err error) {
+	err = tuc.Authorizer.Authorize(ctx, authz.ActionRead, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := search.ParseQuery(query)
+	if parsed.IsEmpty() {
+		return nil, fmt.Errorf("%w: search query must contain at least one word", ErrInvalidArgument)
+	}
+
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	return tuc.TodoRepository.SearchTaskDB(ctx, parsed, limit)
 }
 
 func (tuc *TodoUsecase) Update(ctx context.Context, id int64, data types.Task) (result *types.Task, err error) {
@@ -59,15 +184,33 @@ func (tuc *TodoUsecase) Update(ctx context.Context, id int64, data types.Task) (
 		return nil, err
 	}
 
-	if task == nil {
-		return nil, fmt.Errorf("task with id %d was not found", id)
+	err = tuc.Authorizer.Authorize(ctx, authz.ActionUpdate, task)
+	if err != nil {
+		return nil, err
 	}
 
 	if data.Description != "" {
 		task.Description = data.Description
 	}
 
-	task.Completed = data.Completed
+	// clients that predate statuses only send the completed flag
+	status := data.Status
+	if status == "" {
+		status = tuc.Workflow.StatusFromCompleted(task.Status, data.Completed)
+	}
+
+	if status != task.Status {
+		if !tuc.Workflow.IsValid(status) {
+			return nil, fmt.Errorf("%w: unknown task status %q", ErrInvalidArgument, status)
+		}
+
+		if !tuc.Workflow.CanTransition(task.Status, status) {
+			return nil, fmt.Errorf("%w: task cannot move from status %q to %q", ErrInvalidArgument, task.Status, status)
+		}
+	}
+
+	task.Status = status
+	task.Completed = tuc.Workflow.IsCompleted(status)
 
 	now := time.Now()
 	task.UpdatedAt = &now
@@ -77,23 +220,142 @@ func (tuc *TodoUsecase) Update(ctx context.Context, id int64, data types.Task) (
 		return nil, err
 	}
 
-	result, err = tuc.GetByID(ctx, id)
-	if err != nil && err != sql.ErrNoRows {
+	return tuc.GetByID(ctx, id)
+}
+
+// Move places the task between beforeID and afterID by giving it a rank between theirs,
+// so only the moved task is written. A zero neighbour is looked up from the other one.
+func (tuc *TodoUsecase) Move(ctx context.Context, id int64, beforeID int64, afterID int64) (result *types.Task, err error) {
+	if beforeID == 0 && afterID == 0 {
+		return nil, fmt.Errorf("%w: before id or after id is required", ErrInvalidArgument)
+	}
+
+	if beforeID == id || afterID == id {
+		return nil, fmt.Errorf("%w: task cannot be moved next to itself", ErrInvalidArgument)
+	}
+
+	task, err := tuc.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tuc.Authorizer.Authorize(ctx, authz.ActionUpdate, task)
+	if err != nil {
+		return nil, err
+	}
+
+	// A concurrent move to the same place takes the rank first, the neighbours are then read
+	// again to place the task next to it.
+	for attempt := 1; ; attempt++ {
+		newRank, err := tuc.rankBetween(ctx, id, beforeID, afterID)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		err = tuc.TodoRepository.UpdateRankByIDDB(ctx, id, newRank, &now)
+		if errors.Is(err, todoRepository.ErrDuplicateRank) && attempt < maxRankAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return tuc.GetByID(ctx, id)
+	}
+}
+
+// rankBetween returns a rank for task id between the tasks beforeID and afterID, looking up
+// a zero neighbour from the other one.
+func (tuc *TodoUsecase) rankBetween(ctx context.Context, id int64, beforeID int64, afterID int64) (newRank string, err error) {
+	var lower, upper string
+	if beforeID != 0 {
+		lower, err = tuc.getRank(ctx, beforeID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if afterID != 0 {
+		upper, err = tuc.getRank(ctx, afterID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if afterID == 0 {
+		upper, err = tuc.TodoRepository.GetAdjacentRankDB(ctx, lower, true, id)
+	} else if beforeID == 0 {
+		lower, err = tuc.TodoRepository.GetAdjacentRankDB(ctx, upper, false, id)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// At either end of the list the rank counts away from the neighbour, bisecting toward
+	// an open end would grow the rank by a digit every few moves.
+	switch {
+	case upper == "":
+		return rank.After(lower), nil
+	case lower == "":
+		return rank.Before(upper)
+	}
+
+	newRank, err = rank.Between(lower, upper)
+	if err != nil {
+		return "", fmt.Errorf("%w: task %d must be placed before task %d", ErrInvalidArgument, beforeID, afterID)
+	}
+
+	return newRank, nil
+}
+
+// Assign sets the user working on the task, an empty assigneeID unassigns it.
+func (tuc *TodoUsecase) Assign(ctx context.Context, id int64, assigneeID string) (result *types.Task, err error) {
+	task, err := tuc.GetByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return result, err
+	err = tuc.Authorizer.Authorize(ctx, authz.ActionUpdate, task)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = tuc.TodoRepository.UpdateAssigneeByIDDB(ctx, id, assigneeID, &now)
+	if err != nil {
+		return nil, err
+	}
+
+	return tuc.GetByID(ctx, id)
+}
+
+func (tuc *TodoUsecase) getRank(ctx context.Context, id int64) (string, error) {
+	task, err := tuc.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	// An empty rank would read as "no neighbour" and place the task at either end.
+	if task.Rank == "" {
+		return "", fmt.Errorf("task %d has no rank, apply file/migration/003_task_sort_rank.sql", id)
+	}
+
+	return task.Rank, nil
 }
 
 func (tuc *TodoUsecase) Delete(ctx context.Context, id int64) (err error) {
 	task, err := tuc.GetByID(ctx, id)
-	if err != nil && err != sql.ErrNoRows {
+	if err != nil {
 		return err
 	}
 
-	if task == nil {
-		return fmt.Errorf("task with id %v not found", id)
+	err = tuc.Authorizer.Authorize(ctx, authz.ActionDelete, task)
+	if err != nil {
+		return err
 	}
 
-	return tuc.TodoRepository.DeleteByIDDB(ctx, id)
+	return tuc.AttachmentUsecase.DeleteByTaskID(ctx, id, func(ctx context.Context) error {
+		return tuc.TodoRepository.DeleteByIDDB(ctx, id)
+	})
 }
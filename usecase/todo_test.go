@@ -2,24 +2,36 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
 	"bou.ke/monkey"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/winartodev/go-grpc/auth"
+	"github.com/winartodev/go-grpc/authz"
 	todoRepository "github.com/winartodev/go-grpc/repository/mysql"
 	todoRepositoryMock "github.com/winartodev/go-grpc/repository/mysql/mocks"
+	"github.com/winartodev/go-grpc/search"
 	"github.com/winartodev/go-grpc/types"
+	usecaseMock "github.com/winartodev/go-grpc/usecase/mocks"
+	"github.com/winartodev/go-grpc/workflow"
 )
 
 type todoUsecaseMock struct {
-	TodoRepository *todoRepositoryMock.TodoRepositoryInterface
+	TodoRepository    *todoRepositoryMock.TodoRepositoryInterface
+	AttachmentUsecase *usecaseMock.AttachmentUsecaseInterface
 }
 
 func newTodoUsecaseMock() todoUsecaseMock {
 	return todoUsecaseMock{
-		TodoRepository: new(todoRepositoryMock.TodoRepositoryInterface),
+		TodoRepository:    new(todoRepositoryMock.TodoRepositoryInterface),
+		AttachmentUsecase: new(usecaseMock.AttachmentUsecaseInterface),
 	}
 }
 
@@ -29,7 +41,9 @@ var (
 	dataMock = types.Task{
 		ID:          1,
 		Description: "Create Task",
+		Status:      "todo",
 		Completed:   false,
+		Rank:        "V",
 		CreatedAt:   &mockTime,
 		UpdatedAt:   &mockTime,
 	}
@@ -38,6 +52,7 @@ var (
 		{
 			ID:          1,
 			Description: "Create Task",
+			Status:      "todo",
 			Completed:   false,
 			CreatedAt:   &mockTime,
 			UpdatedAt:   &mockTime,
@@ -47,7 +62,10 @@ var (
 
 func TestNewTodoUsecase(t *testing.T) {
 	type args struct {
-		todoRepository *todoRepository.TodoRepository
+		todoRepository    *todoRepository.TodoRepository
+		attachmentUsecase *AttachmentUsecase
+		workflow          *workflow.Workflow
+		authorizer        authz.AuthorizerInterface
 	}
 	tests := []struct {
 		name string
@@ -57,16 +75,22 @@ func TestNewTodoUsecase(t *testing.T) {
 		{
 			name: "",
 			args: args{
-				todoRepository: &todoRepository.TodoRepository{},
+				todoRepository:    &todoRepository.TodoRepository{},
+				attachmentUsecase: &AttachmentUsecase{},
+				workflow:          workflow.Default(),
+				authorizer:        authz.AllowAll(),
 			},
 			want: &TodoUsecase{
 				&todoRepository.TodoRepository{},
+				&AttachmentUsecase{},
+				workflow.Default(),
+				authz.AllowAll(),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewTodoUsecase(tt.args.todoRepository); !reflect.DeepEqual(got, tt.want) {
+			if got := NewTodoUsecase(tt.args.todoRepository, tt.args.attachmentUsecase, tt.args.workflow, tt.args.authorizer); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewTodoUsecase() = %v, want %v", got, tt.want)
 			}
 		})
@@ -110,6 +134,7 @@ func TestTodoUsecase_Create(t *testing.T) {
 			wantResult: &dataMock,
 			wantErr:    false,
 			mock: func() {
+				todoUsecase.TodoRepository.On("GetLastRankDB", ctx).Return("", nil).Times(1)
 				todoUsecase.TodoRepository.On("Create", ctx, dataMock).Return(dataMock.ID, nil).Times(1)
 				todoUsecase.TodoRepository.On("GetByID", ctx, dataMock.ID).Return(&dataMock, nil).Times(1)
 			},
@@ -126,9 +151,30 @@ func TestTodoUsecase_Create(t *testing.T) {
 			wantResult: nil,
 			wantErr:    true,
 			mock: func() {
+				todoUsecase.TodoRepository.On("GetLastRankDB", ctx).Return("", nil).Times(1)
 				todoUsecase.TodoRepository.On("Create", ctx, dataMock).Return(dataMock.ID, fmt.Errorf("asdf")).Times(1)
 			},
 		},
+		{
+			name: "Retry When Rank Taken Concurrently",
+			fields: fields{
+				TodoRepository: todoUsecase.TodoRepository,
+			},
+			args: args{
+				ctx:  ctx,
+				data: retryDataMock("V"),
+			},
+			wantResult: func() *types.Task { task := retryDataMock("W"); return &task }(),
+			wantErr:    false,
+			mock: func() {
+				todoUsecase.TodoRepository.On("GetLastRankDB", ctx).Return("", nil).Once()
+				todoUsecase.TodoRepository.On("Create", ctx, retryDataMock("V")).Return(int64(0), todoRepository.ErrDuplicateRank).Once()
+				todoUsecase.TodoRepository.On("GetLastRankDB", ctx).Return("V", nil).Once()
+				todoUsecase.TodoRepository.On("Create", ctx, retryDataMock("W")).Return(int64(2), nil).Once()
+				task := retryDataMock("W")
+				todoUsecase.TodoRepository.On("GetByID", ctx, int64(2)).Return(&task, nil).Once()
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt.mock()
@@ -138,6 +184,8 @@ func TestTodoUsecase_Create(t *testing.T) {
 
 			tuc := &TodoUsecase{
 				TodoRepository: tt.fields.TodoRepository,
+				Workflow:       workflow.Default(),
+				Authorizer:     authz.AllowAll(),
 			}
 			gotResult, err := tuc.Create(tt.args.ctx, tt.args.data)
 			if (err != nil) != tt.wantErr {
@@ -151,6 +199,15 @@ func TestTodoUsecase_Create(t *testing.T) {
 	}
 }
 
+// retryDataMock is a task created while another create takes its first rank.
+func retryDataMock(rank string) types.Task {
+	task := dataMock
+	task.ID = 2
+	task.Description = "Retry Task"
+	task.Rank = rank
+	return task
+}
+
 func TestTodoUsecase_GetByID(t *testing.T) {
 	todoUsecase := newTodoUsecaseMock()
 	ctx := context.Background()
@@ -198,6 +255,7 @@ func TestTodoUsecase_GetByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tuc := &TodoUsecase{
 				TodoRepository: tt.fields.TodoRepository,
+				Authorizer:     authz.AllowAll(),
 			}
 			gotResult, err := tuc.GetByID(tt.args.ctx, tt.args.id)
 			if (err != nil) != tt.wantErr {
@@ -214,6 +272,7 @@ func TestTodoUsecase_GetByID(t *testing.T) {
 func TestTodoUsecase_GetAll(t *testing.T) {
 	todoUsecase := newTodoUsecaseMock()
 	ctx := context.Background()
+	callerCtx := auth.NewContext(ctx, auth.Principal{UserID: "bob"})
 
 	monkey.Patch(time.Now, func() time.Time {
 		return mockTime
@@ -225,7 +284,8 @@ func TestTodoUsecase_GetAll(t *testing.T) {
 		TodoRepository todoRepository.TodoRepositoryInterface
 	}
 	type args struct {
-		ctx context.Context
+		ctx    context.Context
+		filter types.TaskFilter
 	}
 	tests := []struct {
 		name       string
@@ -246,9 +306,41 @@ func TestTodoUsecase_GetAll(t *testing.T) {
 			wantResult: dataMockList,
 			wantErr:    false,
 			mock: func() {
-				todoUsecase.TodoRepository.On("GetAllTaskDB", ctx).Return(dataMockList, nil).Times(1)
+				todoUsecase.TodoRepository.On("GetAllTaskDB", ctx, types.TaskFilter{}).Return(dataMockList, nil).Times(1)
 			},
 		},
+		{
+			name: "Assigned To Me",
+			fields: fields{
+				TodoRepository: todoUsecase.TodoRepository,
+			},
+			args: args{
+				ctx: callerCtx,
+				filter: types.TaskFilter{
+					AssignedToMe: true,
+				},
+			},
+			wantResult: dataMockList,
+			wantErr:    false,
+			mock: func() {
+				todoUsecase.TodoRepository.On("GetAllTaskDB", callerCtx, types.TaskFilter{AssigneeID: "bob", AssignedToMe: true}).Return(dataMockList, nil).Times(1)
+			},
+		},
+		{
+			name: "Assigned To Me Without Caller",
+			fields: fields{
+				TodoRepository: todoUsecase.TodoRepository,
+			},
+			args: args{
+				ctx: ctx,
+				filter: types.TaskFilter{
+					AssignedToMe: true,
+				},
+			},
+			wantResult: nil,
+			wantErr:    true,
+			mock:       func() {},
+		},
 	}
 	for _, tt := range tests {
 		tt.mock()
@@ -257,8 +349,9 @@ func TestTodoUsecase_GetAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tuc := &TodoUsecase{
 				TodoRepository: tt.fields.TodoRepository,
+				Authorizer:     authz.AllowAll(),
 			}
-			gotResult, err := tuc.GetAll(tt.args.ctx)
+			gotResult, err := tuc.GetAll(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("TodoUsecase.GetAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -270,6 +363,98 @@ func TestTodoUsecase_GetAll(t *testing.T) {
 	}
 }
 
+func TestTodoUsecase_Search(t *testing.T) {
+	todoUsecase := newTodoUsecaseMock()
+	ctx := context.Background()
+
+	searchResults := []types.TaskSearchResult{
+		{
+			Task:  dataMock,
+			Score: 1.5,
+		},
+	}
+
+	type fields struct {
+		TodoRepository todoRepository.TodoRepositoryInterface
+	}
+	type args struct {
+		ctx   context.Context
+		query string
+		limit int
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantResult []types.TaskSearchResult
+		wantErr    bool
+		mock       func()
+	}{
+		{
+			name: "Success Search Task With Default Limit",
+			fields: fields{
+				TodoRepository: todoUsecase.TodoRepository,
+			},
+			args: args{
+				ctx:   ctx,
+				query: "create",
+			},
+			wantResult: searchResults,
+			wantErr:    false,
+			mock: func() {
+				todoUsecase.TodoRepository.On("SearchTaskDB", ctx, search.Query{Terms: []string{"create"}}, DefaultSearchLimit).Return(searchResults, nil).Times(1)
+			},
+		},
+		{
+			name: "Limit Is Capped",
+			fields: fields{
+				TodoRepository: todoUsecase.TodoRepository,
+			},
+			args: args{
+				ctx:   ctx,
+				query: `"create task"`,
+				limit: 1000,
+			},
+			wantResult: searchResults,
+			wantErr:    false,
+			mock: func() {
+				todoUsecase.TodoRepository.On("SearchTaskDB", ctx, search.Query{Phrases: [][]string{{"create", "task"}}}, MaxSearchLimit).Return(searchResults, nil).Times(1)
+			},
+		},
+		{
+			name: "Empty Query",
+			fields: fields{
+				TodoRepository: todoUsecase.TodoRepository,
+			},
+			args: args{
+				ctx:   ctx,
+				query: "  ",
+			},
+			wantResult: nil,
+			wantErr:    true,
+			mock:       func() {},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+
+		t.Run(tt.name, func(t *testing.T) {
+			tuc := &TodoUsecase{
+				TodoRepository: tt.fields.TodoRepository,
+				Authorizer:     authz.AllowAll(),
+			}
+			gotResult, err := tuc.Search(tt.args.ctx, tt.args.query, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoUsecase.Search() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("TodoUsecase.Search() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
 func TestTodoUsecase_Update(t *testing.T) {
 	todoUsecaseMock := newTodoUsecaseMock()
 	ctx := context.Background()
@@ -314,12 +499,91 @@ func TestTodoUsecase_Update(t *testing.T) {
 			},
 		},
 	}
+
+	teamWorkflow, _ := workflow.New("todo", "done", map[string][]string{
+		"todo":        {"in_progress"},
+		"in_progress": {"todo", "review"},
+		"review":      {"in_progress", "done"},
+		"done":        {"todo"},
+	})
+
+	inReview := dataMock
+	inReview.Status = "review"
+
+	done := dataMock
+	done.Status = "done"
+	done.Completed = true
+
+	inProgress := dataMock
+	inProgress.Status = "in_progress"
+
+	transitionTests := []struct {
+		name       string
+		current    types.Task
+		data       types.Task
+		wantUpdate *types.Task
+		wantErr    bool
+	}{
+		{
+			name:       "Allowed Transition",
+			current:    inReview,
+			data:       types.Task{Status: "done"},
+			wantUpdate: &done,
+		},
+		{
+			name:    "Transition Not Allowed",
+			current: dataMock,
+			data:    types.Task{Status: "done"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Status",
+			current: dataMock,
+			data:    types.Task{Status: "blocked"},
+			wantErr: true,
+		},
+		{
+			name:       "Legacy Completed Flag",
+			current:    inReview,
+			data:       types.Task{Completed: true},
+			wantUpdate: &done,
+		},
+		{
+			name:       "Legacy Flag Keeps Current Status",
+			current:    inProgress,
+			data:       types.Task{Completed: false},
+			wantUpdate: &inProgress,
+		},
+	}
+	for _, tt := range transitionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTodoUsecaseMock()
+			current := tt.current
+			m.TodoRepository.On("GetByID", ctx, int64(1)).Return(&current, nil)
+			if tt.wantUpdate != nil {
+				m.TodoRepository.On("UpdateByIDDB", ctx, int64(1), *tt.wantUpdate).Return(nil).Once()
+			}
+
+			tuc := &TodoUsecase{
+				TodoRepository: m.TodoRepository,
+				Workflow:       teamWorkflow,
+				Authorizer:     authz.AllowAll(),
+			}
+			_, err := tuc.Update(ctx, 1, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoUsecase.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			m.TodoRepository.AssertExpectations(t)
+		})
+	}
 	for _, tt := range tests {
 		tt.mock()
 		defer tt.mock()
 		t.Run(tt.name, func(t *testing.T) {
 			tuc := &TodoUsecase{
 				TodoRepository: tt.fields.TodoRepository,
+				Workflow:       workflow.Default(),
+				Authorizer:     authz.AllowAll(),
 			}
 			gotResult, err := tuc.Update(tt.args.ctx, tt.args.id, tt.args.data)
 			if (err != nil) != tt.wantErr {
@@ -333,9 +597,272 @@ func TestTodoUsecase_Update(t *testing.T) {
 	}
 }
 
+func TestTodoUsecase_Assign(t *testing.T) {
+	todoUsecase := newTodoUsecaseMock()
+	ctx := context.Background()
+
+	monkey.Patch(time.Now, func() time.Time {
+		return mockTime
+	})
+	defer monkey.UnpatchAll()
+
+	assigned := dataMock
+	assigned.AssigneeID = "bob"
+
+	type fields struct {
+		TodoRepository todoRepository.TodoRepositoryInterface
+	}
+	type args struct {
+		ctx        context.Context
+		id         int64
+		assigneeID string
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantResult *types.Task
+		wantErr    bool
+		mock       func()
+	}{
+		{
+			name: "Success Assign Task",
+			fields: fields{
+				TodoRepository: todoUsecase.TodoRepository,
+			},
+			args: args{
+				ctx:        ctx,
+				id:         1,
+				assigneeID: "bob",
+			},
+			wantResult: &assigned,
+			wantErr:    false,
+			mock: func() {
+				todoUsecase.TodoRepository.On("GetByID", ctx, int64(1)).Return(&dataMock, nil).Once()
+				todoUsecase.TodoRepository.On("UpdateAssigneeByIDDB", ctx, int64(1), "bob", &mockTime).Return(nil).Once()
+				todoUsecase.TodoRepository.On("GetByID", ctx, int64(1)).Return(&assigned, nil).Once()
+			},
+		},
+		{
+			name: "Task Not Found",
+			fields: fields{
+				TodoRepository: todoUsecase.TodoRepository,
+			},
+			args: args{
+				ctx:        ctx,
+				id:         2,
+				assigneeID: "bob",
+			},
+			wantResult: nil,
+			wantErr:    true,
+			mock: func() {
+				todoUsecase.TodoRepository.On("GetByID", ctx, int64(2)).Return(nil, sql.ErrNoRows).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			tuc := &TodoUsecase{
+				TodoRepository: tt.fields.TodoRepository,
+				Authorizer:     authz.AllowAll(),
+			}
+			gotResult, err := tuc.Assign(tt.args.ctx, tt.args.id, tt.args.assigneeID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoUsecase.Assign() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("TodoUsecase.Assign() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestTodoUsecase_Move(t *testing.T) {
+	ctx := context.Background()
+
+	monkey.Patch(time.Now, func() time.Time {
+		return mockTime
+	})
+	defer monkey.UnpatchAll()
+
+	taskWithRank := func(id int64, rank string) *types.Task {
+		return &types.Task{ID: id, Description: "Create Task", Rank: rank, CreatedAt: &mockTime}
+	}
+
+	type args struct {
+		ctx      context.Context
+		id       int64
+		beforeID int64
+		afterID  int64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult *types.Task
+		wantErr    bool
+		mock       func(m todoUsecaseMock)
+	}{
+		{
+			name: "Move Between Two Tasks",
+			args: args{
+				ctx:      ctx,
+				id:       1,
+				beforeID: 2,
+				afterID:  3,
+			},
+			wantResult: taskWithRank(1, "aV"),
+			wantErr:    false,
+			mock: func(m todoUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "V"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(2)).Return(taskWithRank(2, "a"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(3)).Return(taskWithRank(3, "b"), nil).Once()
+				m.TodoRepository.On("UpdateRankByIDDB", ctx, int64(1), "aV", &mockTime).Return(nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "aV"), nil).Once()
+			},
+		},
+		{
+			name: "Move After Task Looks Up Next Neighbour",
+			args: args{
+				ctx:      ctx,
+				id:       1,
+				beforeID: 2,
+			},
+			wantResult: taskWithRank(1, "aV"),
+			wantErr:    false,
+			mock: func(m todoUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "V"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(2)).Return(taskWithRank(2, "a"), nil).Once()
+				m.TodoRepository.On("GetAdjacentRankDB", ctx, "a", true, int64(1)).Return("b", nil).Once()
+				m.TodoRepository.On("UpdateRankByIDDB", ctx, int64(1), "aV", &mockTime).Return(nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "aV"), nil).Once()
+			},
+		},
+		{
+			name: "Move To End",
+			args: args{
+				ctx:      ctx,
+				id:       1,
+				beforeID: 2,
+			},
+			wantResult: taskWithRank(1, "b"),
+			wantErr:    false,
+			mock: func(m todoUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "V"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(2)).Return(taskWithRank(2, "a"), nil).Once()
+				m.TodoRepository.On("GetAdjacentRankDB", ctx, "a", true, int64(1)).Return("", nil).Once()
+				m.TodoRepository.On("UpdateRankByIDDB", ctx, int64(1), "b", &mockTime).Return(nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "b"), nil).Once()
+			},
+		},
+		{
+			name: "Move To Start",
+			args: args{
+				ctx:     ctx,
+				id:      1,
+				afterID: 2,
+			},
+			wantResult: taskWithRank(1, "U"),
+			wantErr:    false,
+			mock: func(m todoUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "k"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(2)).Return(taskWithRank(2, "V"), nil).Once()
+				m.TodoRepository.On("GetAdjacentRankDB", ctx, "V", false, int64(1)).Return("", nil).Once()
+				m.TodoRepository.On("UpdateRankByIDDB", ctx, int64(1), "U", &mockTime).Return(nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "U"), nil).Once()
+			},
+		},
+		{
+			name: "Neighbours In Wrong Order",
+			args: args{
+				ctx:      ctx,
+				id:       1,
+				beforeID: 3,
+				afterID:  2,
+			},
+			wantResult: nil,
+			wantErr:    true,
+			mock: func(m todoUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "V"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(3)).Return(taskWithRank(3, "b"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(2)).Return(taskWithRank(2, "a"), nil).Once()
+			},
+		},
+		{
+			name: "Retry When Rank Taken Concurrently",
+			args: args{
+				ctx:      ctx,
+				id:       1,
+				beforeID: 2,
+				afterID:  3,
+			},
+			wantResult: taskWithRank(1, "ak"),
+			wantErr:    false,
+			mock: func(m todoUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "V"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(2)).Return(taskWithRank(2, "a"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(3)).Return(taskWithRank(3, "b"), nil).Once()
+				m.TodoRepository.On("UpdateRankByIDDB", ctx, int64(1), "aV", &mockTime).Return(todoRepository.ErrDuplicateRank).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(2)).Return(taskWithRank(2, "aV"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(3)).Return(taskWithRank(3, "b"), nil).Once()
+				m.TodoRepository.On("UpdateRankByIDDB", ctx, int64(1), "ak", &mockTime).Return(nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "ak"), nil).Once()
+			},
+		},
+		{
+			name: "Neighbour Without Rank",
+			args: args{
+				ctx:      ctx,
+				id:       1,
+				beforeID: 2,
+				afterID:  3,
+			},
+			wantResult: nil,
+			wantErr:    true,
+			mock: func(m todoUsecaseMock) {
+				m.TodoRepository.On("GetByID", ctx, int64(1)).Return(taskWithRank(1, "V"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(2)).Return(taskWithRank(2, "a"), nil).Once()
+				m.TodoRepository.On("GetByID", ctx, int64(3)).Return(taskWithRank(3, ""), nil).Once()
+			},
+		},
+		{
+			name: "Missing Neighbours",
+			args: args{
+				ctx: ctx,
+				id:  1,
+			},
+			wantResult: nil,
+			wantErr:    true,
+			mock:       func(m todoUsecaseMock) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTodoUsecaseMock()
+			tt.mock(m)
+
+			tuc := &TodoUsecase{
+				TodoRepository: m.TodoRepository,
+				Authorizer:     authz.AllowAll(),
+			}
+			gotResult, err := tuc.Move(tt.args.ctx, tt.args.id, tt.args.beforeID, tt.args.afterID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoUsecase.Move() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("TodoUsecase.Move() = %v, want %v", gotResult, tt.wantResult)
+			}
+			m.TodoRepository.AssertExpectations(t)
+		})
+	}
+}
+
 func TestTodoUsecase_Delete(t *testing.T) {
 	todoUsecaseMock := newTodoUsecaseMock()
 	ctx := context.Background()
+	editorCtx := auth.NewContext(ctx, auth.Principal{UserID: "bob", Role: authz.RoleEditor})
 
 	monkey.Patch(time.Now, func() time.Time {
 		return mockTime
@@ -343,7 +870,9 @@ func TestTodoUsecase_Delete(t *testing.T) {
 	defer monkey.UnpatchAll()
 
 	type fields struct {
-		TodoRepository todoRepository.TodoRepositoryInterface
+		TodoRepository    todoRepository.TodoRepositoryInterface
+		AttachmentUsecase AttachmentUsecaseInterface
+		Authorizer        authz.AuthorizerInterface
 	}
 	type args struct {
 		ctx context.Context
@@ -359,7 +888,9 @@ func TestTodoUsecase_Delete(t *testing.T) {
 		{
 			name: "Success Delete Task",
 			fields: fields{
-				TodoRepository: todoUsecaseMock.TodoRepository,
+				TodoRepository:    todoUsecaseMock.TodoRepository,
+				AttachmentUsecase: todoUsecaseMock.AttachmentUsecase,
+				Authorizer:        authz.AllowAll(),
 			},
 			args: args{
 				ctx: ctx,
@@ -368,7 +899,26 @@ func TestTodoUsecase_Delete(t *testing.T) {
 			wantErr: false,
 			mock: func() {
 				todoUsecaseMock.TodoRepository.On("GetByID", ctx, int64(1)).Return(&dataMock, nil)
-				todoUsecaseMock.TodoRepository.On("DeleteByIDDB", ctx, int64(1)).Return(nil)
+				todoUsecaseMock.AttachmentUsecase.On("DeleteByTaskID", ctx, int64(1), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(2).(func(context.Context) error)(ctx)
+				})
+				todoUsecaseMock.TodoRepository.On("DeleteByIDDB", ctx, int64(1)).Return(nil).Once()
+			},
+		},
+		{
+			name: "Editor Cannot Delete Task Created By Someone Else",
+			fields: fields{
+				TodoRepository:    todoUsecaseMock.TodoRepository,
+				AttachmentUsecase: todoUsecaseMock.AttachmentUsecase,
+				Authorizer:        authz.NewRoleAuthorizer(authz.RoleViewer),
+			},
+			args: args{
+				ctx: editorCtx,
+				id:  int64(1),
+			},
+			wantErr: true,
+			mock: func() {
+				todoUsecaseMock.TodoRepository.On("GetByID", editorCtx, int64(1)).Return(&dataMock, nil)
 			},
 		},
 	}
@@ -377,7 +927,9 @@ func TestTodoUsecase_Delete(t *testing.T) {
 		defer tt.mock()
 		t.Run(tt.name, func(t *testing.T) {
 			tuc := &TodoUsecase{
-				TodoRepository: tt.fields.TodoRepository,
+				TodoRepository:    tt.fields.TodoRepository,
+				AttachmentUsecase: tt.fields.AttachmentUsecase,
+				Authorizer:        tt.fields.Authorizer,
 			}
 			if err := tuc.Delete(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("TodoUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
@@ -385,3 +937,118 @@ func TestTodoUsecase_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoUsecase_OtherTenant(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), auth.Principal{UserID: "mallory", TenantID: "globex"})
+
+	tests := []struct {
+		name string
+		call func(tuc *TodoUsecase) error
+	}{
+		{
+			name: "Get",
+			call: func(tuc *TodoUsecase) error {
+				_, err := tuc.GetByID(ctx, 1)
+				return err
+			},
+		},
+		{
+			name: "Update",
+			call: func(tuc *TodoUsecase) error {
+				_, err := tuc.Update(ctx, 1, types.Task{Description: "mine now"})
+				return err
+			},
+		},
+		{
+			name: "Assign",
+			call: func(tuc *TodoUsecase) error {
+				_, err := tuc.Assign(ctx, 1, "mallory")
+				return err
+			},
+		},
+		{
+			name: "Delete",
+			call: func(tuc *TodoUsecase) error {
+				return tuc.Delete(ctx, 1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbmock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			dbmock.ExpectQuery(regexp.QuoteMeta(todoRepository.GetTaskByID)).
+				WithArgs("globex", int64(1)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "description", "status", "complete", "sort_rank", "assignee_id", "creator_id", "created_at", "updated_at"}))
+
+			tuc := &TodoUsecase{
+				TodoRepository: todoRepository.NewTodoRepository(db),
+				Workflow:       workflow.Default(),
+				Authorizer:     authz.AllowAll(),
+			}
+
+			if err := tt.call(tuc); !errors.Is(err, ErrNotFound) {
+				t.Errorf("error = %v, want %v", err, ErrNotFound)
+			}
+
+			if err := dbmock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestTodoUsecase_InvalidArgument(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func(tuc *TodoUsecase) error
+	}{
+		{
+			name: "Unknown Status",
+			call: func(tuc *TodoUsecase) error {
+				_, err := tuc.Create(ctx, types.Task{Description: "write tests", Status: "archived"})
+				return err
+			},
+		},
+		{
+			name: "Caller Filter Without Caller",
+			call: func(tuc *TodoUsecase) error {
+				_, err := tuc.GetAll(ctx, types.TaskFilter{AssignedToMe: true})
+				return err
+			},
+		},
+		{
+			name: "Empty Search Query",
+			call: func(tuc *TodoUsecase) error {
+				_, err := tuc.Search(ctx, "  ", 0)
+				return err
+			},
+		},
+		{
+			name: "Move Without Neighbours",
+			call: func(tuc *TodoUsecase) error {
+				_, err := tuc.Move(ctx, 1, 0, 0)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tuc := &TodoUsecase{
+				TodoRepository: new(todoRepositoryMock.TodoRepositoryInterface),
+				Workflow:       workflow.Default(),
+				Authorizer:     authz.AllowAll(),
+			}
+
+			if err := tt.call(tuc); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("error = %v, want %v", err, ErrInvalidArgument)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package traced wraps the todo usecase so every method runs in its own span.
+package traced
+
+import (
+	"context"
+
+	"github.com/winartodev/go-grpc/tracing"
+	"github.com/winartodev/go-grpc/types"
+	"github.com/winartodev/go-grpc/usecase"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TodoUsecase struct {
+	Usecase usecase.TodoUsecaseInterface
+}
+
+func NewTodoUsecase(todoUsecase usecase.TodoUsecaseInterface) usecase.TodoUsecaseInterface {
+	return &TodoUsecase{
+		Usecase: todoUsecase,
+	}
+}
+
+func (tuc *TodoUsecase) start(ctx context.Context, method string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "TodoUsecase."+method, trace.WithAttributes(attributes...))
+}
+
+func (tuc *TodoUsecase) Create(ctx context.Context, data types.Task) (result *types.Task, err error) {
+	ctx, span := tuc.start(ctx, "Create")
+	defer tracing.End(span, &err)
+	return tuc.Usecase.Create(ctx, data)
+}
+
+func (tuc *TodoUsecase) GetByID(ctx context.Context, id int64) (result *types.Task, err error) {
+	ctx, span := tuc.start(ctx, "GetByID", attribute.Int64("task.id", id))
+	defer tracing.End(span, &err)
+	return tuc.Usecase.GetByID(ctx, id)
+}
+
+func (tuc *TodoUsecase) GetAll(ctx context.Context, filter types.TaskFilter) (result []types.Task, err error) {
+	ctx, span := tuc.start(ctx, "GetAll")
+	defer tracing.End(span, &err)
+	return tuc.Usecase.GetAll(ctx, filter)
+}
+
+func (tuc *TodoUsecase) Search(ctx context.Context, query string, limit int) (result []types.TaskSearchResult, err error) {
+	ctx, span := tuc.start(ctx, "Search", attribute.Int("search.limit", limit))
+	defer tracing.End(span, &err)
+	return tuc.Usecase.Search(ctx, query, limit)
+}
+
+func (tuc *TodoUsecase) Update(ctx context.Context, id int64, data types.Task) (result *types.Task, err error) {
+	ctx, span := tuc.start(ctx, "Update", attribute.Int64("task.id", id))
+	defer tracing.End(span, &err)
+	return tuc.Usecase.Update(ctx, id, data)
+}
+
+func (tuc *TodoUsecase) Move(ctx context.Context, id int64, beforeID int64, afterID int64) (result *types.Task, err error) {
+	ctx, span := tuc.start(ctx, "Move", attribute.Int64("task.id", id))
+	defer tracing.End(span, &err)
+	return tuc.Usecase.Move(ctx, id, beforeID, afterID)
+}
+
+func (tuc *TodoUsecase) Assign(ctx context.Context, id int64, assigneeID string) (result *types.Task, err error) {
+	ctx, span := tuc.start(ctx, "Assign", attribute.Int64("task.id", id))
+	defer tracing.End(span, &err)
+	return tuc.Usecase.Assign(ctx, id, assigneeID)
+}
+
+func (tuc *TodoUsecase) Delete(ctx context.Context, id int64) (err error) {
+	ctx, span := tuc.start(ctx, "Delete", attribute.Int64("task.id", id))
+	defer tracing.End(span, &err)
+	return tuc.Usecase.Delete(ctx, id)
+}
@@ -0,0 +1,48 @@
+package traced
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/winartodev/go-grpc/types"
+	todoUsecaseMock "github.com/winartodev/go-grpc/usecase/mocks"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTodoUsecase_TracesMethods(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	var usecaseSpan trace.SpanContext
+	todoUsecase := new(todoUsecaseMock.TodoUsecaseInterface)
+	todoUsecase.On("GetByID", mock.Anything, int64(1)).Return(&types.Task{ID: 1}, nil).Run(func(args mock.Arguments) {
+		usecaseSpan = trace.SpanContextFromContext(args.Get(0).(context.Context))
+	})
+	todoUsecase.On("Delete", mock.Anything, int64(2)).Return(errors.New("task not found"))
+
+	tuc := NewTodoUsecase(todoUsecase)
+
+	if task, err := tuc.GetByID(context.Background(), 1); err != nil || task.ID != 1 {
+		t.Fatalf("TodoUsecase.GetByID() = %v, %v", task, err)
+	}
+	if err := tuc.Delete(context.Background(), 2); err == nil {
+		t.Fatalf("TodoUsecase.Delete() error = nil, want an error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended spans = %d, want 2", len(spans))
+	}
+	if spans[0].Name() != "TodoUsecase.GetByID" || spans[0].SpanContext().SpanID() != usecaseSpan.SpanID() {
+		t.Errorf("span 0 = %s, want TodoUsecase.GetByID passed down to the usecase", spans[0].Name())
+	}
+	if spans[1].Name() != "TodoUsecase.Delete" || spans[1].Status().Code != codes.Error {
+		t.Errorf("span 1 = %s (%v), want TodoUsecase.Delete (error)", spans[1].Name(), spans[1].Status().Code)
+	}
+}
@@ -15,7 +15,11 @@ func TransformTaskData(rpcdata *todolist.Task) (result types.Task) {
 	result = types.Task{
 		ID:          rpcdata.Id,
 		Description: rpcdata.Description,
+		Status:      rpcdata.Status,
 		Completed:   rpcdata.Completed,
+		Rank:        rpcdata.Rank,
+		AssigneeID:  rpcdata.AssigneeId,
+		CreatorID:   rpcdata.CreatorId,
 		CreatedAt:   &createdAt,
 		UpdatedAt:   &updatedAt,
 	}
@@ -35,10 +39,81 @@ func TransformTaskDataRPC(data *types.Task) (result *todolist.Task) {
 	result = &todolist.Task{
 		Id:          data.ID,
 		Description: data.Description,
+		Status:      data.Status,
 		Completed:   data.Completed,
+		Rank:        data.Rank,
+		AssigneeId:  data.AssigneeID,
+		CreatorId:   data.CreatorID,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 	}
 
 	return result
 }
+
+func TransformTaskSearchResultRPC(data *types.TaskSearchResult) (result *todolist.TaskSearchResult) {
+
+	result = &todolist.TaskSearchResult{
+		Task:  TransformTaskDataRPC(&data.Task),
+		Score: data.Score,
+	}
+
+	return result
+}
+
+func TransformAttachmentInfoData(rpcdata *todolist.AttachmentInfo) (result types.Attachment) {
+
+	result = types.Attachment{
+		TaskID:      rpcdata.TaskId,
+		Name:        rpcdata.Name,
+		ContentType: rpcdata.ContentType,
+	}
+
+	return result
+}
+
+func TransformAttachmentDataRPC(data *types.Attachment) (result *todolist.Attachment) {
+
+	var createdAt int64
+	if data.CreatedAt != nil {
+		createdAt = data.CreatedAt.Unix()
+	}
+
+	result = &todolist.Attachment{
+		Id:          data.ID,
+		TaskId:      data.TaskID,
+		Name:        data.Name,
+		Size:        data.Size,
+		ContentType: data.ContentType,
+		Sha256:      data.SHA256,
+		CreatedAt:   createdAt,
+	}
+
+	return result
+}
+
+func TransformAPIKeyDataRPC(data *types.APIKey) (result *todolist.ApiKey) {
+
+	result = &todolist.ApiKey{
+		Id:         data.ID,
+		Name:       data.Name,
+		Prefix:     data.Prefix,
+		Scopes:     data.Scopes,
+		ExpiresAt:  unixOrZero(data.ExpiresAt),
+		RevokedAt:  unixOrZero(data.RevokedAt),
+		LastUsedAt: unixOrZero(data.LastUsedAt),
+		LastUsedIp: data.LastUsedIP,
+		CreatorId:  data.CreatorID,
+		CreatedAt:  unixOrZero(data.CreatedAt),
+	}
+
+	return result
+}
+
+func unixOrZero(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+
+	return t.Unix()
+}
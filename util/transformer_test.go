@@ -21,7 +21,9 @@ func TestTransformTaskData(t *testing.T) {
 	rpcData := &todolist.Task{
 		Id:          1,
 		Description: "Description",
+		Status:      "todo",
 		Completed:   false,
+		Rank:        "V",
 		CreatedAt:   mockTime.Unix(),
 		UpdatedAt:   mockTime.Unix(),
 	}
@@ -31,7 +33,9 @@ func TestTransformTaskData(t *testing.T) {
 	taskData := types.Task{
 		ID:          1,
 		Description: "Description",
+		Status:      "todo",
 		Completed:   false,
+		Rank:        "V",
 		CreatedAt:   &unixTime,
 		UpdatedAt:   &unixTime,
 	}
@@ -72,7 +76,9 @@ func TestTransformTaskDataRPC(t *testing.T) {
 	rpcData := &todolist.Task{
 		Id:          1,
 		Description: "Description",
+		Status:      "todo",
 		Completed:   false,
+		Rank:        "V",
 		CreatedAt:   mockTime.Unix(),
 		UpdatedAt:   mockTime.Unix(),
 	}
@@ -82,7 +88,9 @@ func TestTransformTaskDataRPC(t *testing.T) {
 	taskData := types.Task{
 		ID:          1,
 		Description: "Description",
+		Status:      "todo",
 		Completed:   false,
+		Rank:        "V",
 		CreatedAt:   &unixTime,
 		UpdatedAt:   &unixTime,
 	}
@@ -111,3 +119,82 @@ func TestTransformTaskDataRPC(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformAttachmentInfoData(t *testing.T) {
+	type args struct {
+		rpcdata *todolist.AttachmentInfo
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult types.Attachment
+	}{
+		{
+			name: "Success Transform RPC Attachment Info to Data",
+			args: args{
+				rpcdata: &todolist.AttachmentInfo{
+					TaskId:      1,
+					Name:        "notes.txt",
+					ContentType: "text/plain",
+				},
+			},
+			wantResult: types.Attachment{
+				TaskID:      1,
+				Name:        "notes.txt",
+				ContentType: "text/plain",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotResult := TransformAttachmentInfoData(tt.args.rpcdata); !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("TransformAttachmentInfoData() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestTransformAttachmentDataRPC(t *testing.T) {
+	mockTime := time.Date(2020, 10, 25, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		data *types.Attachment
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult *todolist.Attachment
+	}{
+		{
+			name: "Success Transform Attachment Data to RPC",
+			args: args{
+				data: &types.Attachment{
+					ID:          1,
+					TaskID:      1,
+					Name:        "notes.txt",
+					Size:        5,
+					ContentType: "text/plain",
+					SHA256:      "sha",
+					StorageKey:  "1/abc",
+					CreatedAt:   &mockTime,
+				},
+			},
+			wantResult: &todolist.Attachment{
+				Id:          1,
+				TaskId:      1,
+				Name:        "notes.txt",
+				Size:        5,
+				ContentType: "text/plain",
+				Sha256:      "sha",
+				CreatedAt:   mockTime.Unix(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotResult := TransformAttachmentDataRPC(tt.args.data); !reflect.DeepEqual(gotResult, tt.wantResult) {
+				t.Errorf("TransformAttachmentDataRPC() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
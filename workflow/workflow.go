@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	DefaultInitial = "todo"
+	DefaultDone    = "done"
+)
+
+// Workflow describes the statuses a task can be in and which moves between them are allowed.
+// Done is the status reported as completed to clients that only know the boolean flag.
+type Workflow struct {
+	Initial     string
+	Done        string
+	Transitions map[string][]string
+}
+
+// New validates the workflow: initial and done must be known statuses and every
+// transition must lead to a known status. A status is known once it has a transitions entry.
+func New(initial string, done string, transitions map[string][]string) (*Workflow, error) {
+	if _, ok := transitions[initial]; !ok {
+		return nil, fmt.Errorf("workflow: initial status %q has no transitions entry", initial)
+	}
+
+	if _, ok := transitions[done]; !ok {
+		return nil, fmt.Errorf("workflow: done status %q has no transitions entry", done)
+	}
+
+	for from, targets := range transitions {
+		for _, to := range targets {
+			if _, ok := transitions[to]; !ok {
+				return nil, fmt.Errorf("workflow: status %q transitions to unknown status %q", from, to)
+			}
+		}
+	}
+
+	return &Workflow{
+		Initial:     initial,
+		Done:        done,
+		Transitions: transitions,
+	}, nil
+}
+
+// Default is the two status workflow equivalent to the former completed flag.
+func Default() *Workflow {
+	return &Workflow{
+		Initial: DefaultInitial,
+		Done:    DefaultDone,
+		Transitions: map[string][]string{
+			DefaultInitial: {DefaultDone},
+			DefaultDone:    {DefaultInitial},
+		},
+	}
+}
+
+func (w *Workflow) IsValid(status string) bool {
+	_, ok := w.Transitions[status]
+	return ok
+}
+
+func (w *Workflow) IsCompleted(status string) bool {
+	return status == w.Done
+}
+
+func (w *Workflow) CanTransition(from string, to string) bool {
+	for _, target := range w.Transitions[from] {
+		if target == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusFromCompleted maps the completed flag of older clients onto a status: completing
+// moves the task to done, un-completing a done task reopens it and anything else keeps it as is.
+func (w *Workflow) StatusFromCompleted(current string, completed bool) string {
+	if completed {
+		return w.Done
+	}
+
+	if w.IsCompleted(current) {
+		return w.Initial
+	}
+
+	return current
+}
+
+func (w *Workflow) Statuses() []string {
+	statuses := make([]string, 0, len(w.Transitions))
+	for status := range w.Transitions {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	return statuses
+}
@@ -0,0 +1,155 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+var teamTransitions = map[string][]string{
+	"todo":        {"in_progress"},
+	"in_progress": {"todo", "review"},
+	"review":      {"in_progress", "done"},
+	"done":        {"todo"},
+}
+
+func TestNew(t *testing.T) {
+	type args struct {
+		initial     string
+		done        string
+		transitions map[string][]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Valid Workflow",
+			args: args{
+				initial:     "todo",
+				done:        "done",
+				transitions: teamTransitions,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unknown Initial Status",
+			args: args{
+				initial:     "backlog",
+				done:        "done",
+				transitions: teamTransitions,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown Done Status",
+			args: args{
+				initial:     "todo",
+				done:        "closed",
+				transitions: teamTransitions,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Transition To Unknown Status",
+			args: args{
+				initial: "todo",
+				done:    "done",
+				transitions: map[string][]string{
+					"todo": {"blocked"},
+					"done": {},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.args.initial, tt.args.done, tt.args.transitions)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflow_CanTransition(t *testing.T) {
+	w, _ := New("todo", "done", teamTransitions)
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want bool
+	}{
+		{
+			name: "Allowed",
+			from: "review",
+			to:   "done",
+			want: true,
+		},
+		{
+			name: "Skipping Review",
+			from: "in_progress",
+			to:   "done",
+			want: false,
+		},
+		{
+			name: "Unknown Status",
+			from: "blocked",
+			to:   "todo",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("Workflow.CanTransition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflow_StatusFromCompleted(t *testing.T) {
+	w, _ := New("todo", "done", teamTransitions)
+
+	tests := []struct {
+		name      string
+		current   string
+		completed bool
+		want      string
+	}{
+		{
+			name:      "Complete",
+			current:   "review",
+			completed: true,
+			want:      "done",
+		},
+		{
+			name:      "Reopen",
+			current:   "done",
+			completed: false,
+			want:      "todo",
+		},
+		{
+			name:      "Keep In Progress",
+			current:   "in_progress",
+			completed: false,
+			want:      "in_progress",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.StatusFromCompleted(tt.current, tt.completed); got != tt.want {
+				t.Errorf("Workflow.StatusFromCompleted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Statuses(t *testing.T) {
+	want := []string{"done", "in_progress", "review", "todo"}
+	if got := (&Workflow{Transitions: teamTransitions}).Statuses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Workflow.Statuses() = %v, want %v", got, want)
+	}
+}